@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+)
+
+var configCommand = &cli.Command{
+	Name:  "config",
+	Usage: "Inspect consensus configs",
+	Commands: []*cli.Command{
+		{
+			Name:  "show",
+			Usage: "Print the effective consensus config values with their source (config, preset, default or override)",
+			Flags: []cli.Flag{
+				configFlag, configURLFlag, networkFlag, strictConfigFlag, presetFileFlag, presetDirFlag, configSetFlag, configEnvPrefixFlag,
+			},
+			Action:    runConfigShow,
+			UsageText: "eth-beacon-genesis config show --config config.yaml|--config-url http://beacon:5052|--network mainnet [--set KEY=VALUE]",
+		},
+	},
+}
+
+// configOverrides collects repeated --set flags. Unlike slice flags, values are not split at commas,
+// so list values like "[1, 2]" can be passed as is.
+type configOverrides []string
+
+func (o *configOverrides) Set(value string) error {
+	*o = append(*o, value)
+	return nil
+}
+
+func (o *configOverrides) Get() any {
+	return []string(*o)
+}
+
+func (o *configOverrides) String() string {
+	if o == nil {
+		return ""
+	}
+
+	return strings.Join(*o, ", ")
+}
+
+// getConfigOverrides returns the --set values of a command (none if the command has no --set flag).
+func getConfigOverrides(cmd *cli.Command) []string {
+	overrides, ok := cmd.Value(configSetFlag.Name).([]string)
+	if !ok {
+		return nil
+	}
+
+	return overrides
+}
+
+// getConfigSourceName returns the consensus config file, beacon node URL or embedded network given via command line flags.
+func getConfigSourceName(cmd *cli.Command) string {
+	if configFile := cmd.String(configFlag.Name); configFile != "" {
+		return configFile
+	}
+
+	if configURL := cmd.String(configURLFlag.Name); configURL != "" {
+		return configURL
+	}
+
+	return "network:" + cmd.String(networkFlag.Name)
+}
+
+// loadConsensusConfig loads the consensus config from the file, beacon node or embedded network config given via
+// command line flags, with the preset and override settings of the command line flags.
+func loadConsensusConfig(ctx context.Context, cmd *cli.Command) (*config.Config, error) {
+	configFile := cmd.String(configFlag.Name)
+	configURL := cmd.String(configURLFlag.Name)
+	network := cmd.String(networkFlag.Name)
+
+	configSources := 0
+
+	for _, source := range []string{configFile, configURL, network} {
+		if source != "" {
+			configSources++
+		}
+	}
+
+	if configSources != 1 {
+		return nil, fmt.Errorf("exactly one of --%v, --%v or --%v is required", configFlag.Name, configURLFlag.Name, networkFlag.Name)
+	}
+
+	options := &config.LoadOptions{
+		Strict:     cmd.Bool(strictConfigFlag.Name),
+		Overrides:  getConfigOverrides(cmd),
+		EnvPrefix:  cmd.String(configEnvPrefixFlag.Name),
+		PresetFile: cmd.String(presetFileFlag.Name),
+		PresetDir:  cmd.String(presetDirFlag.Name),
+	}
+
+	if configURL != "" {
+		clConfig, err := config.LoadConfigFromURL(ctx, configURL, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load consensus config from beacon node: %w", err)
+		}
+
+		return clConfig, nil
+	}
+
+	if network != "" {
+		clConfig, err := config.LoadNetworkConfig(network, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load consensus config: %w", err)
+		}
+
+		return clConfig, nil
+	}
+
+	clConfig, err := config.LoadConfigWithOptions(configFile, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load consensus config: %w", err)
+	}
+
+	return clConfig, nil
+}
+
+func runConfigShow(ctx context.Context, cmd *cli.Command) error {
+	clConfig, err := loadConsensusConfig(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "KEY\tVALUE\tSOURCE")
+
+	for _, value := range clConfig.EffectiveValues() {
+		fmt.Fprintf(writer, "%v\t%v\t%v\n", value.Key, value.FormatValue(), value.Source)
+	}
+
+	return writer.Flush()
+}
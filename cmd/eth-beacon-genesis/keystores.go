@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/ethpandaops/eth-beacon-genesis/validators"
+)
+
+var (
+	keystoresMnemonicsFlag = &cli.StringFlag{
+		Name:     "mnemonics",
+		Usage:    "Path to the (optionally encrypted) mnemonics file to derive the validator keys from (- for stdin)",
+		Required: true,
+	}
+	keystoresPartitionsFlag = &cli.StringFlag{
+		Name:     "partitions",
+		Usage:    "Path to the partition spec file assigning validators to nodes",
+		Required: true,
+	}
+	keystoresOutputFlag = &cli.StringFlag{
+		Name:     "output",
+		Usage:    "Path to the directory to write the per-node keystore directories to",
+		Required: true,
+	}
+	keystoresPasswordFileFlag = &cli.StringFlag{
+		Name:  "password-file",
+		Usage: "Path to a file with the password for all keystores (random password per keystore if not set)",
+	}
+	keystoresInsecureFlag = &cli.BoolFlag{
+		Name:  "insecure",
+		Usage: "Use a minimal KDF cost for fast keystore encryption (test networks only)",
+	}
+
+	keystoresCommand = &cli.Command{
+		Name:  "keystores",
+		Usage: "Generate validator keystores",
+		Commands: []*cli.Command{
+			{
+				Name:  "split",
+				Usage: "Split the validators of a mnemonics file across nodes and write per-node keystore directories",
+				Flags: []cli.Flag{
+					keystoresMnemonicsFlag, keystoresPartitionsFlag, keystoresOutputFlag, keystoresPasswordFileFlag, keystoresInsecureFlag,
+				},
+				Action:    runKeystoresSplit,
+				UsageText: "eth-beacon-genesis keystores split --mnemonics mnemonics.yaml --partitions nodes.yaml --output keys",
+			},
+		},
+	}
+)
+
+func runKeystoresSplit(_ context.Context, cmd *cli.Command) error {
+	options := &validators.KeystoreOptions{
+		Insecure: cmd.Bool(keystoresInsecureFlag.Name),
+	}
+
+	if passwordFile := cmd.String(keystoresPasswordFileFlag.Name); passwordFile != "" {
+		password, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return fmt.Errorf("failed to read password file: %w", err)
+		}
+
+		options.Password = strings.TrimRight(string(password), "\r\n")
+	}
+
+	return validators.GenerateNodeKeystores(
+		cmd.String(keystoresMnemonicsFlag.Name),
+		cmd.String(keystoresPartitionsFlag.Name),
+		cmd.String(keystoresOutputFlag.Name),
+		options,
+	)
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
-	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v3"
@@ -25,18 +29,98 @@ var (
 		Required: true,
 	}
 	configFlag = &cli.StringFlag{
-		Name:     "config",
-		Usage:    "Path to consensus genesis config (config.yaml)",
-		Required: true,
+		Name:  "config",
+		Usage: "Path to consensus genesis config (config.yaml)",
+	}
+	configURLFlag = &cli.StringFlag{
+		Name:  "config-url",
+		Usage: "URL of a beacon node to fetch the consensus config from (/eth/v1/config/spec), instead of --config",
+	}
+	networkFlag = &cli.StringFlag{
+		Name:  "network",
+		Usage: "Name of a public network to use the embedded consensus config of (" + strings.Join(config.EmbeddedNetworks(), ", ") + "), instead of --config",
+	}
+	strictConfigFlag = &cli.BoolFlag{
+		Name:  "strict-config",
+		Usage: "Fail on missing required consensus config values instead of falling back to defaults",
+	}
+	presetFileFlag = &cli.StringFlag{
+		Name:  "preset-file",
+		Usage: "Path to a preset file to use instead of the preset referenced by PRESET_BASE",
+	}
+	presetDirFlag = &cli.StringFlag{
+		Name:  "preset-dir",
+		Usage: "Path to a directory with custom presets, searched for the preset referenced by PRESET_BASE before the embedded presets",
+	}
+	configSetFlag = &cli.GenericFlag{
+		Name:  "set",
+		Usage: "Override a consensus config value (KEY=VALUE, can be specified multiple times)",
+		Value: &configOverrides{},
+	}
+	configEnvPrefixFlag = &cli.StringFlag{
+		Name:  "config-env-prefix",
+		Usage: "Prefix of environment variables overriding consensus config values, e.g. ETH_BEACON_GENESIS_CONFIG_ (disabled if not set)",
 	}
 	mnemonicsFileFlag = &cli.StringFlag{
 		Name:  "mnemonics",
-		Usage: "Path to the file containing the mnemonics for genesis validators",
+		Usage: "Path to the (optionally encrypted) file containing the mnemonics for genesis validators (- for stdin)",
 	}
 	validatorsFileFlag = &cli.StringFlag{
 		Name:  "additional-validators",
 		Usage: "Path to the file with a list of additional genesis validators validators",
 	}
+	depositDataFlag = &cli.StringSliceFlag{
+		Name:  "deposit-data",
+		Usage: "Path to a deposit_data-*.json file with additional genesis validators (can be specified multiple times)",
+	}
+	keystoresFileFlag = &cli.StringFlag{
+		Name:  "keystores",
+		Usage: "Path to the file describing directories of EIP-2335 keystores for additional genesis validators",
+	}
+	importValidatorsFlag = &cli.StringFlag{
+		Name:  "import-validators",
+		Usage: "Path or URL to a beacon state (SSZ) or /eth/v1/beacon/states/{id}/validators response (JSON) to import validators from",
+	}
+	importValidatorsStatusFlag = &cli.StringSliceFlag{
+		Name:  "import-validators-status",
+		Usage: "Only import validators with the given status or status group (pending, active, exited, withdrawal)",
+	}
+	importValidatorsRangeFlag = &cli.StringFlag{
+		Name:  "import-validators-range",
+		Usage: "Only import validators within the given index range (start:end, end exclusive)",
+	}
+	importValidatorsEffectiveBalanceFlag = &cli.BoolFlag{
+		Name:  "import-validators-effective-balance",
+		Usage: "Use the effective balance instead of the actual balance of imported validators",
+	}
+	interopValidatorsFlag = &cli.UintFlag{
+		Name:  "interop-validators",
+		Usage: "Number of validators with deterministic interop keys to add",
+	}
+	interopValidatorsStartFlag = &cli.UintFlag{
+		Name:  "interop-validators-start",
+		Usage: "Index of the first interop validator key",
+	}
+	interopFlag = &cli.BoolFlag{
+		Name:  "interop",
+		Usage: "Build the genesis state with the interop conventions (not byte-for-byte identical to client interop genesis states, requires interop validators only)",
+	}
+	interopGenesisTimeFlag = &cli.UintFlag{
+		Name:  "interop-genesis-time",
+		Usage: "Genesis time for the interop mode (defaults to MIN_GENESIS_TIME or the current time)",
+	}
+	syntheticValidatorsFlag = &cli.UintFlag{
+		Name:  "synthetic-validators",
+		Usage: "Number of synthetic non-signing validators to add for scale testing",
+	}
+	syntheticValidatorsSeedFlag = &cli.UintFlag{
+		Name:  "synthetic-validators-seed",
+		Usage: "Seed for the synthetic validator public keys",
+	}
+	syntheticWithdrawalAddressFlag = &cli.StringFlag{
+		Name:  "synthetic-withdrawal-address",
+		Usage: "Withdrawal address for 0x01 credentials of synthetic validators (0x00 credentials if not set)",
+	}
 	shadowForkBlockFlag = &cli.StringFlag{
 		Name:  "shadow-fork-block",
 		Usage: "Path to the file with a execution block to create a shadow fork from",
@@ -53,6 +137,52 @@ var (
 		Name:  "json-output",
 		Usage: "Path to the file to write the genesis state to in JSON format",
 	}
+	withdrawalAddressesOutputFlag = &cli.StringFlag{
+		Name:  "withdrawal-addresses-output",
+		Usage: "Path to the file to write the withdrawal address to validator index mapping to in JSON format",
+	}
+	validatorRangesOutputFlag = &cli.StringFlag{
+		Name:  "validator-ranges-output",
+		Usage: "Path to the file to write the validator index ranges, counts and stake share per source to in YAML format",
+	}
+	validatorsCSVOutputFlag = &cli.StringFlag{
+		Name:  "validators-csv-output",
+		Usage: "Path to the file to write the index, pubkey, source and derivation path of all validators to in CSV format",
+	}
+	configOutputFlag = &cli.StringFlag{
+		Name:  "config-output",
+		Usage: "Path to the file to write the effective consensus config (with overrides and defaults) to in YAML format",
+	}
+	metadataOutputFlag = &cli.StringFlag{
+		Name:  "metadata-output",
+		Usage: "Path to the file to write the build metadata (version, config file and overrides) to in JSON format",
+	}
+
+	genesisTimeFlag = &cli.StringFlag{
+		Name:  "genesis-time",
+		Usage: "Genesis time as unix timestamp or RFC3339 time, instead of MIN_GENESIS_TIME + GENESIS_DELAY",
+	}
+	genesisInFlag = &cli.DurationFlag{
+		Name:  "genesis-in",
+		Usage: "Genesis time as duration from now (e.g. 5m), instead of MIN_GENESIS_TIME + GENESIS_DELAY",
+	}
+	genesisTimeAlignFlag = &cli.BoolFlag{
+		Name:  "genesis-time-align",
+		Usage: "Round the genesis time up to a multiple of SECONDS_PER_SLOT",
+	}
+	eth1AdjustTimestampFlag = &cli.BoolFlag{
+		Name:  "eth1-adjust-timestamp",
+		Usage: "Set the execution genesis timestamp to the genesis time and move the time based execution forks along",
+	}
+	eth1ConfigOutputFlag = &cli.StringFlag{
+		Name:  "eth1-config-output",
+		Usage: "Path to the file to write the (adjusted) execution genesis config to",
+	}
+	lintFailOnFlag = &cli.StringFlag{
+		Name:  "lint-fail-on",
+		Usage: "Fail the build on genesis lint issues of the given severity or higher (none, warning or error)",
+		Value: "none",
+	}
 
 	quietFlag = &cli.BoolFlag{
 		Name:    "quiet",
@@ -68,13 +198,21 @@ var (
 				Name:  "devnet",
 				Usage: "Generate a devnet genesis state",
 				Flags: []cli.Flag{
-					eth1ConfigFlag, configFlag, mnemonicsFileFlag, validatorsFileFlag,
+					eth1ConfigFlag, configFlag, configURLFlag, networkFlag, strictConfigFlag, presetFileFlag, presetDirFlag, configSetFlag, configEnvPrefixFlag, mnemonicsFileFlag, validatorsFileFlag, depositDataFlag,
+					keystoresFileFlag, importValidatorsFlag, importValidatorsStatusFlag, importValidatorsRangeFlag,
+					importValidatorsEffectiveBalanceFlag, interopValidatorsFlag, interopValidatorsStartFlag, interopFlag, interopGenesisTimeFlag,
+					genesisTimeFlag, genesisInFlag, genesisTimeAlignFlag, eth1AdjustTimestampFlag,
+					syntheticValidatorsFlag, syntheticValidatorsSeedFlag, syntheticWithdrawalAddressFlag,
 					shadowForkBlockFlag, shadowForkRPCFlag, stateOutputFlag, jsonOutputFlag,
-					quietFlag,
+					withdrawalAddressesOutputFlag, validatorRangesOutputFlag, validatorsCSVOutputFlag, configOutputFlag, eth1ConfigOutputFlag, metadataOutputFlag, lintFailOnFlag, quietFlag,
 				},
 				Action:    runDevnet,
 				UsageText: "eth-beacon-genesis devnet [options]",
 			},
+			mnemonicsCommand,
+			keystoresCommand,
+			configCommand,
+			retimeCommand,
 			{
 				Name:  "version",
 				Usage: "Print the version of the application",
@@ -97,15 +235,25 @@ func main() {
 
 func runDevnet(ctx context.Context, cmd *cli.Command) error {
 	eth1Config := cmd.String(eth1ConfigFlag.Name)
-	eth2Config := cmd.String(configFlag.Name)
-	mnemonicsFile := cmd.String(mnemonicsFileFlag.Name)
-	validatorsFile := cmd.String(validatorsFileFlag.Name)
+	interopValidators := cmd.Uint(interopValidatorsFlag.Name)
+	interop := cmd.Bool(interopFlag.Name)
 	shadowForkBlock := cmd.String(shadowForkBlockFlag.Name)
 	shadowForkRPC := cmd.String(shadowForkRPCFlag.Name)
-	stateOutputFile := cmd.String(stateOutputFlag.Name)
-	jsonOutputFile := cmd.String(jsonOutputFlag.Name)
 	quiet := cmd.Bool(quietFlag.Name)
 
+	lintFailOn, err := generator.ParseLintSeverity(cmd.String(lintFailOnFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	if interop && (interopValidators == 0 || shadowForkBlock != "" || shadowForkRPC != "") {
+		return fmt.Errorf("interop mode requires --%v and cannot be combined with a shadow fork", interopValidatorsFlag.Name)
+	}
+
+	if cmd.Bool(eth1AdjustTimestampFlag.Name) && (shadowForkBlock != "" || shadowForkRPC != "") {
+		return fmt.Errorf("--%v cannot be combined with a shadow fork", eth1AdjustTimestampFlag.Name)
+	}
+
 	if quiet {
 		logrus.SetLevel(logrus.PanicLevel)
 	}
@@ -121,42 +269,40 @@ func runDevnet(ctx context.Context, cmd *cli.Command) error {
 
 	logrus.Infof("loaded execution genesis. chainid: %v", elGenesis.Config.ChainID.String())
 
-	clConfig, err := config.LoadConfig(eth2Config)
+	clConfig, err := loadConsensusConfig(ctx, cmd)
 	if err != nil {
-		return fmt.Errorf("failed to load consensus config: %w", err)
+		return err
 	}
 
-	logrus.Infof("loaded consensus config. genesis fork version: 0x%x", clConfig.GetBytesDefault("GENESIS_FORK_VERSION", []byte{}))
+	logrus.Infof("loaded consensus config. genesis fork version: 0x%x", clConfig.GetBytesOrDefault("GENESIS_FORK_VERSION"))
 
-	var clValidators []*validators.Validator
-
-	if mnemonicsFile != "" {
-		vals, err2 := validators.GenerateValidatorsByMnemonic(mnemonicsFile)
-		if err2 != nil {
-			return fmt.Errorf("failed to load validators from mnemonics file: %w", err2)
-		}
+	validatorSources, err := loadValidatorSources(cmd, clConfig)
+	if err != nil {
+		return err
+	}
 
-		if len(vals) > 0 {
-			clValidators = vals
-		}
+	if err := validators.ValidateSources(validatorSources); err != nil {
+		return fmt.Errorf("invalid validators: %w", err)
 	}
 
-	if validatorsFile != "" {
-		vals, err2 := validators.LoadValidatorsFromFile(validatorsFile)
-		if err2 != nil {
-			return fmt.Errorf("failed to load validators from file: %w", err2)
-		}
+	var clValidators []*validators.Validator
 
-		if len(vals) > 0 {
-			clValidators = append(clValidators, vals...)
+	for _, source := range validatorSources {
+		// validators without explicit source name are named after their source
+		for _, validator := range source.Validators {
+			if validator.Source == "" {
+				validator.Source = source.Name
+			}
 		}
+
+		clValidators = append(clValidators, source.Validators...)
 	}
 
 	if len(clValidators) == 0 {
 		return fmt.Errorf("no validators found")
 	}
 
-	defaultBalance := clConfig.GetUintDefault("MAX_EFFECTIVE_BALANCE", 32_000_000_000)
+	defaultBalance := clConfig.GetUintOrDefault("MAX_EFFECTIVE_BALANCE")
 	totalBalance := uint64(0)
 
 	for _, val := range clValidators {
@@ -172,30 +318,21 @@ func runDevnet(ctx context.Context, cmd *cli.Command) error {
 	builder := generator.NewGenesisBuilder(elGenesis, clConfig)
 	builder.AddValidators(clValidators)
 
-	if shadowForkBlock != "" || shadowForkRPC != "" {
-		var gensisBlock *types.Block
-
-		if shadowForkBlock != "" {
-			block, err2 := eth1.LoadBlockFromFile(shadowForkBlock)
-			if err2 != nil {
-				return fmt.Errorf("failed to load shadow fork block from file: %w", err2)
-			}
-
-			logrus.Infof("loaded shadow fork block from file. hash: %s", block.Hash().String())
+	genesisBlockTime := elGenesis.Timestamp
 
-			gensisBlock = block
-		} else {
-			block, err2 := eth1.GetBlockFromRPC(ctx, shadowForkRPC)
-			if err2 != nil {
-				return fmt.Errorf("failed to get shadow fork block: %w", err2)
-			}
+	shadowForkGenesisBlock, err := loadShadowForkBlock(ctx, cmd)
+	if err != nil {
+		return err
+	}
 
-			logrus.Infof("loaded shadow fork block from RPC. hash: %s", block.Hash().String())
+	if shadowForkGenesisBlock != nil {
+		builder.SetShadowForkBlock(shadowForkGenesisBlock)
 
-			gensisBlock = block
-		}
+		genesisBlockTime = shadowForkGenesisBlock.Time()
+	}
 
-		builder.SetShadowForkBlock(gensisBlock)
+	if err := setDevnetGenesisTime(cmd, clConfig, builder, elGenesis, genesisBlockTime); err != nil {
+		return err
 	}
 
 	genesisState, err := builder.BuildState()
@@ -205,42 +342,189 @@ func runDevnet(ctx context.Context, cmd *cli.Command) error {
 
 	logrus.Infof("successfully built genesis state.")
 
-	if stateOutputFile != "" {
-		sszData, err := builder.Serialize(genesisState, http.ContentTypeSSZ)
+	if err := lintGenesis(clConfig, genesisState, lintFailOn); err != nil {
+		return err
+	}
+
+	if err := writeValidatorOutputs(cmd, clValidators, defaultBalance); err != nil {
+		return err
+	}
+
+	if err := writeBuildOutputs(cmd, clConfig, elGenesis, genesisState, clValidators); err != nil {
+		return err
+	}
+
+	return writeStateOutputs(cmd, clConfig, genesisState)
+}
+
+// loadShadowForkBlock loads the execution block given via --shadow-fork-block or --shadow-fork-rpc.
+// Returns nil if no shadow fork is configured.
+func loadShadowForkBlock(ctx context.Context, cmd *cli.Command) (*types.Block, error) {
+	shadowForkBlock := cmd.String(shadowForkBlockFlag.Name)
+	shadowForkRPC := cmd.String(shadowForkRPCFlag.Name)
+
+	switch {
+	case shadowForkBlock != "":
+		block, err := eth1.LoadBlockFromFile(shadowForkBlock)
 		if err != nil {
-			return fmt.Errorf("failed to serialize genesis state: %w", err)
+			return nil, fmt.Errorf("failed to load shadow fork block from file: %w", err)
 		}
 
-		if err := os.WriteFile(stateOutputFile, sszData, 0o644); err != nil { //nolint:gosec // no strict permissions needed
-			return fmt.Errorf("failed to write genesis state to SSZ file: %w", err)
+		logrus.Infof("loaded shadow fork block from file. hash: %s", block.Hash().String())
+
+		return block, nil
+	case shadowForkRPC != "":
+		block, err := eth1.GetBlockFromRPC(ctx, shadowForkRPC)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get shadow fork block: %w", err)
 		}
 
-		logrus.Infof("serialized genesis state to SSZ file: %s", stateOutputFile)
+		logrus.Infof("loaded shadow fork block from RPC. hash: %s", block.Hash().String())
+
+		return block, nil
+	default:
+		return nil, nil
+	}
+}
+
+// setDevnetGenesisTime sets the genesis time of the builder from the genesis time flags, applies the interop genesis
+// and moves the execution genesis timestamp along with --eth1-adjust-timestamp.
+func setDevnetGenesisTime(cmd *cli.Command, clConfig *config.Config, builder generator.GenesisBuilder, elGenesis *core.Genesis, genesisBlockTime uint64) error {
+	interop := cmd.Bool(interopFlag.Name)
+
+	defaultGenesisTime := uint64(0)
+	if !interop {
+		defaultGenesisTime = generator.GetGenesisTime(clConfig, genesisBlockTime)
+	}
+
+	genesisTime, err := getGenesisTime(cmd, clConfig, defaultGenesisTime)
+	if err != nil {
+		return err
 	}
 
-	if jsonOutputFile != "" {
-		jsonData, err := builder.Serialize(genesisState, http.ContentTypeJSON)
+	if interop {
+		interopGenesis, err := getInteropGenesis(cmd, clConfig, cmd.Uint(interopValidatorsStartFlag.Name), cmd.Uint(interopValidatorsFlag.Name), genesisTime)
 		if err != nil {
-			return fmt.Errorf("failed to serialize genesis state: %w", err)
+			return err
 		}
 
-		if err := os.WriteFile(jsonOutputFile, jsonData, 0o644); err != nil { //nolint:gosec // no strict permissions needed
-			return fmt.Errorf("failed to write genesis state to JSON file: %w", err)
-		}
+		builder.SetInteropGenesis(interopGenesis)
+
+		genesisTime = interopGenesis.GenesisTime
+	}
 
-		if !quiet {
-			fmt.Printf("serialized genesis state to JSON file: %s\n", jsonOutputFile)
+	if cmd.Bool(eth1AdjustTimestampFlag.Name) {
+		if genesisTime == 0 {
+			genesisTime = generator.GetGenesisTime(clConfig, genesisBlockTime)
 		}
+
+		eth1.SetGenesisTimestamp(elGenesis, genesisTime)
+
+		logrus.Infof("adjusted execution genesis timestamp to %v. block hash: %s", genesisTime, elGenesis.ToBlock().Hash().String())
+	}
+
+	builder.SetGenesisTime(genesisTime)
+
+	return nil
+}
+
+// lintGenesis reports the genesis lint issues and fails if an issue has the failOn severity or higher.
+func lintGenesis(clConfig *config.Config, state *spec.VersionedBeaconState, failOn generator.LintSeverity) error {
+	issues, err := generator.LintGenesis(clConfig, state)
+	if err != nil {
+		return fmt.Errorf("failed to lint genesis: %w", err)
+	}
+
+	for _, issue := range issues {
+		logrus.Warnf("genesis lint %v", issue)
+	}
+
+	if maxSeverity := generator.MaxLintSeverity(issues); failOn != 0 && maxSeverity >= failOn {
+		return fmt.Errorf("genesis lint failed with %v issues (--%v %v)", maxSeverity, lintFailOnFlag.Name, failOn)
 	}
 
-	if stateOutputFile == "" && jsonOutputFile == "" {
-		jsonData, err := builder.Serialize(genesisState, http.ContentTypeJSON)
+	return nil
+}
+
+// getGenesisTime returns the genesis time given via --genesis-time or --genesis-in, aligned to the slot duration
+// with --genesis-time-align (which also aligns the given default genesis time). Returns 0 if the default applies.
+func getGenesisTime(cmd *cli.Command, clConfig *config.Config, defaultGenesisTime uint64) (uint64, error) {
+	genesisTimeValue := cmd.String(genesisTimeFlag.Name)
+	genesisIn := cmd.Duration(genesisInFlag.Name)
+
+	if genesisTimeValue != "" && genesisIn != 0 {
+		return 0, fmt.Errorf("--%v and --%v cannot be combined", genesisTimeFlag.Name, genesisInFlag.Name)
+	}
+
+	genesisTime := uint64(0)
+
+	switch {
+	case genesisTimeValue != "":
+		timestamp, err := utils.ParseGenesisTime(genesisTimeValue)
 		if err != nil {
-			return fmt.Errorf("failed to serialize genesis state: %w", err)
+			return 0, err
 		}
 
-		fmt.Println(string(jsonData))
+		genesisTime = timestamp
+	case genesisIn < 0:
+		return 0, fmt.Errorf("invalid --%v %v (negative duration)", genesisInFlag.Name, genesisIn)
+	case genesisIn > 0:
+		genesisTime = uint64(time.Now().Add(genesisIn).Unix()) //nolint:gosec // no overflow
 	}
 
-	return nil
+	if cmd.Bool(genesisTimeAlignFlag.Name) {
+		if genesisTime == 0 {
+			genesisTime = defaultGenesisTime
+		}
+
+		genesisTime = utils.AlignGenesisTime(genesisTime, clConfig.GetUintOrDefault("SECONDS_PER_SLOT"))
+	}
+
+	if genesisTime != 0 {
+		logrus.Infof("using genesis time: %v (%v)", genesisTime, time.Unix(int64(genesisTime), 0).UTC().Format(time.RFC3339)) //nolint:gosec // no overflow
+	}
+
+	return genesisTime, nil
+}
+
+// getInteropGenesis signs the genesis deposits of the interop validators and returns the interop genesis settings.
+// The genesis time defaults to the given genesis time, MIN_GENESIS_TIME or the current time.
+func getInteropGenesis(cmd *cli.Command, clConfig *config.Config, start, count, defaultGenesisTime uint64) (*generator.InteropGenesis, error) {
+	genesisTime := cmd.Uint(interopGenesisTimeFlag.Name)
+	if genesisTime == 0 {
+		genesisTime = defaultGenesisTime
+	}
+
+	if genesisTime == 0 {
+		genesisTime = clConfig.GetUintOrDefault("MIN_GENESIS_TIME")
+	}
+
+	if genesisTime == 0 {
+		genesisTime = uint64(time.Now().Unix()) //nolint:gosec // no overflow
+	}
+
+	if cmd.Bool(genesisTimeAlignFlag.Name) {
+		genesisTime = utils.AlignGenesisTime(genesisTime, clConfig.GetUintOrDefault("SECONDS_PER_SLOT"))
+	}
+
+	genesisForkVersion := clConfig.GetBytesOrDefault("GENESIS_FORK_VERSION")
+	depositAmount := clConfig.GetUintOrDefault("MAX_EFFECTIVE_BALANCE")
+
+	depositData, err := validators.GenerateInteropDepositData(start, count, depositAmount, phase0.Version(genesisForkVersion))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate interop deposits: %w", err)
+	}
+
+	depositRoot, err := utils.ComputeDepositDataRoot(clConfig, depositData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute interop deposit root: %w", err)
+	}
+
+	logrus.Infof("using interop genesis. genesis time: %v, deposit root: 0x%x", genesisTime, depositRoot)
+
+	return &generator.InteropGenesis{
+		GenesisTime:  genesisTime,
+		DepositRoot:  depositRoot,
+		DepositCount: uint64(len(depositData)),
+	}, nil
 }
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/spec"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+	"github.com/ethpandaops/eth-beacon-genesis/utils"
+	"github.com/ethpandaops/eth-beacon-genesis/validators"
+)
+
+// buildMetadata describes the inputs of a genesis build.
+type buildMetadata struct {
+	Version               string             `json:"version"`
+	GenesisFork           string             `json:"genesis_fork"`
+	GenesisForkDigest     string             `json:"genesis_fork_digest"`
+	GenesisValidatorsRoot string             `json:"genesis_validators_root"`
+	ConfigFile            string             `json:"config_file"`
+	ConfigOverrides       []*config.Override `json:"config_overrides"`
+	ValidatorCount        uint64             `json:"validator_count"`
+	NonSigningCount       uint64             `json:"non_signing_validator_count"`
+}
+
+// writeBuildMetadata writes the build metadata of a genesis state as JSON file.
+func writeBuildMetadata(path, configFile string, clConfig *config.Config, state *spec.VersionedBeaconState, clValidators []*validators.Validator) error {
+	genesisValidatorsRoot, err := utils.GetGenesisValidatorsRoot(state)
+	if err != nil {
+		return err
+	}
+
+	forkDigest, err := utils.ComputeForkDigest(clConfig, genesisValidatorsRoot, 0)
+	if err != nil {
+		return err
+	}
+
+	metadata := &buildMetadata{
+		Version:               utils.GetBuildVersion(),
+		GenesisFork:           state.Version.String(),
+		GenesisForkDigest:     fmt.Sprintf("0x%x", forkDigest),
+		GenesisValidatorsRoot: fmt.Sprintf("0x%x", genesisValidatorsRoot),
+		ConfigFile:            configFile,
+		ConfigOverrides:       clConfig.Overrides(),
+		ValidatorCount:        uint64(len(clValidators)),
+	}
+
+	for _, validator := range clValidators {
+		if validator.NonSigning {
+			metadata.NonSigningCount++
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize build metadata: %w", err)
+	}
+
+	if err := os.WriteFile(path, jsonData, 0o644); err != nil { //nolint:gosec // no strict permissions needed
+		return fmt.Errorf("failed to write build metadata file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/ethpandaops/eth-beacon-genesis/validators"
+)
+
+var (
+	mnemonicsInputFlag = &cli.StringFlag{
+		Name:     "input",
+		Usage:    "Path to the plaintext mnemonics file to encrypt (- for stdin)",
+		Required: true,
+	}
+	mnemonicsOutputFlag = &cli.StringFlag{
+		Name:     "output",
+		Usage:    "Path to the file to write the encrypted mnemonics file to",
+		Required: true,
+	}
+	mnemonicsSourcesFlag = &cli.IntFlag{
+		Name:  "sources",
+		Usage: "Number of mnemonics to generate",
+		Value: 1,
+	}
+	mnemonicsCountFlag = &cli.UintFlag{
+		Name:     "count",
+		Usage:    "Number of validators per mnemonic",
+		Required: true,
+	}
+	mnemonicsBalanceFlag = &cli.UintFlag{
+		Name:  "balance",
+		Usage: "Balance of the validators in gwei (defaults to MAX_EFFECTIVE_BALANCE)",
+	}
+	mnemonicsWdTypeFlag = &cli.StringFlag{
+		Name:  "wd-type",
+		Usage: "Withdrawal credentials type (bls, eth1 or compounding)",
+		Value: "bls",
+	}
+	mnemonicsWdAddressFlag = &cli.StringFlag{
+		Name:  "wd-address",
+		Usage: "Withdrawal address for eth1 and compounding credentials",
+	}
+	mnemonicsWdAddressPathFlag = &cli.StringFlag{
+		Name:  "wd-address-path",
+		Usage: "Derivation path template for per-validator withdrawal addresses (e.g. m/44'/60'/0'/0/{index})",
+	}
+	mnemonicsWithdrawalMnemonicFlag = &cli.BoolFlag{
+		Name:  "withdrawal-mnemonic",
+		Usage: "Generate a separate withdrawal mnemonic for each source",
+	}
+	mnemonicsNewOutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "Path to the file to write the mnemonics file to (prints to stdout if not set)",
+	}
+	mnemonicsEncryptFlag = &cli.BoolFlag{
+		Name:  "encrypt",
+		Usage: "Encrypt the mnemonics file with a passphrase (read from " + validators.MnemonicsPassphraseEnv + " or prompted)",
+	}
+
+	mnemonicsCommand = &cli.Command{
+		Name:  "mnemonics",
+		Usage: "Manage mnemonics files",
+		Commands: []*cli.Command{
+			{
+				Name:      "encrypt",
+				Usage:     "Encrypt a mnemonics file with a passphrase (read from " + validators.MnemonicsPassphraseEnv + " or prompted)",
+				Flags:     []cli.Flag{mnemonicsInputFlag, mnemonicsOutputFlag},
+				Action:    runMnemonicsEncrypt,
+				UsageText: "eth-beacon-genesis mnemonics encrypt --input mnemonics.yaml --output mnemonics.enc.json",
+			},
+			{
+				Name:  "new",
+				Usage: "Generate a mnemonics file with fresh random mnemonics",
+				Flags: []cli.Flag{
+					mnemonicsSourcesFlag, mnemonicsCountFlag, mnemonicsBalanceFlag, mnemonicsWdTypeFlag, mnemonicsWdAddressFlag,
+					mnemonicsWdAddressPathFlag, mnemonicsWithdrawalMnemonicFlag, mnemonicsNewOutputFlag, mnemonicsEncryptFlag,
+				},
+				Action:    runMnemonicsNew,
+				UsageText: "eth-beacon-genesis mnemonics new --sources 2 --count 100 --output mnemonics.yaml",
+			},
+		},
+	}
+)
+
+func runMnemonicsEncrypt(_ context.Context, cmd *cli.Command) error {
+	inputFile := cmd.String(mnemonicsInputFlag.Name)
+	outputFile := cmd.String(mnemonicsOutputFlag.Name)
+
+	var (
+		plaintext []byte
+		err       error
+	)
+
+	if inputFile == "-" {
+		plaintext, err = io.ReadAll(os.Stdin)
+	} else {
+		plaintext, err = os.ReadFile(inputFile)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read mnemonics file: %w", err)
+	}
+
+	passphrase, err := validators.GetMnemonicsPassphrase(true)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := validators.EncryptMnemonics(plaintext, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt mnemonics file: %w", err)
+	}
+
+	if err := os.WriteFile(outputFile, encrypted, 0o600); err != nil {
+		return fmt.Errorf("failed to write encrypted mnemonics file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "wrote encrypted mnemonics file: %s\n", outputFile)
+
+	return nil
+}
+
+func runMnemonicsNew(_ context.Context, cmd *cli.Command) error {
+	outputFile := cmd.String(mnemonicsNewOutputFlag.Name)
+	encrypt := cmd.Bool(mnemonicsEncryptFlag.Name)
+
+	if encrypt && outputFile == "" {
+		return fmt.Errorf("--%v requires --%v", mnemonicsEncryptFlag.Name, mnemonicsNewOutputFlag.Name)
+	}
+
+	template := &validators.MnemonicSrc{
+		Count:         cmd.Uint(mnemonicsCountFlag.Name),
+		Balance:       cmd.Uint(mnemonicsBalanceFlag.Name),
+		WdType:        cmd.String(mnemonicsWdTypeFlag.Name),
+		WdAddress:     cmd.String(mnemonicsWdAddressFlag.Name),
+		WdAddressPath: cmd.String(mnemonicsWdAddressPathFlag.Name),
+	}
+
+	mnemonicSrcs, err := validators.NewMnemonicSrcs(int(cmd.Int(mnemonicsSourcesFlag.Name)), template, cmd.Bool(mnemonicsWithdrawalMnemonicFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	data, err := validators.MarshalMnemonics(mnemonicSrcs)
+	if err != nil {
+		return fmt.Errorf("failed to serialize mnemonics file: %w", err)
+	}
+
+	if outputFile == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if encrypt {
+		passphrase, err := validators.GetMnemonicsPassphrase(true)
+		if err != nil {
+			return err
+		}
+
+		data, err = validators.EncryptMnemonics(data, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt mnemonics file: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(outputFile, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write mnemonics file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "wrote mnemonics file with %d mnemonics: %s\n", len(mnemonicSrcs), outputFile)
+
+	return nil
+}
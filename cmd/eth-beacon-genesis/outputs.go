@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+	"github.com/ethpandaops/eth-beacon-genesis/generator"
+	"github.com/ethpandaops/eth-beacon-genesis/validators"
+)
+
+// writeStateOutputs writes the genesis state to the --state-output (SSZ) and --json-output files,
+// or prints it as JSON if neither is set.
+func writeStateOutputs(cmd *cli.Command, clConfig *config.Config, state *spec.VersionedBeaconState) error {
+	stateOutputFile := cmd.String(stateOutputFlag.Name)
+	jsonOutputFile := cmd.String(jsonOutputFlag.Name)
+
+	if stateOutputFile != "" {
+		sszData, err := generator.SerializeState(clConfig, state, http.ContentTypeSSZ)
+		if err != nil {
+			return fmt.Errorf("failed to serialize genesis state: %w", err)
+		}
+
+		if err := os.WriteFile(stateOutputFile, sszData, 0o644); err != nil { //nolint:gosec // no strict permissions needed
+			return fmt.Errorf("failed to write genesis state to SSZ file: %w", err)
+		}
+
+		logrus.Infof("serialized genesis state to SSZ file: %s", stateOutputFile)
+	}
+
+	if jsonOutputFile != "" {
+		jsonData, err := generator.SerializeState(clConfig, state, http.ContentTypeJSON)
+		if err != nil {
+			return fmt.Errorf("failed to serialize genesis state: %w", err)
+		}
+
+		if err := os.WriteFile(jsonOutputFile, jsonData, 0o644); err != nil { //nolint:gosec // no strict permissions needed
+			return fmt.Errorf("failed to write genesis state to JSON file: %w", err)
+		}
+
+		if !cmd.Bool(quietFlag.Name) {
+			fmt.Printf("serialized genesis state to JSON file: %s\n", jsonOutputFile)
+		}
+	}
+
+	if stateOutputFile == "" && jsonOutputFile == "" {
+		jsonData, err := generator.SerializeState(clConfig, state, http.ContentTypeJSON)
+		if err != nil {
+			return fmt.Errorf("failed to serialize genesis state: %w", err)
+		}
+
+		fmt.Println(string(jsonData))
+	}
+
+	return nil
+}
+
+// writeValidatorOutputs writes the withdrawal addresses, validator ranges and validators CSV output files.
+func writeValidatorOutputs(cmd *cli.Command, clValidators []*validators.Validator, defaultBalance uint64) error {
+	if withdrawalAddressesOutputFile := cmd.String(withdrawalAddressesOutputFlag.Name); withdrawalAddressesOutputFile != "" {
+		jsonData, err := json.MarshalIndent(validators.GetWithdrawalAddresses(clValidators), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to serialize withdrawal addresses: %w", err)
+		}
+
+		if err := os.WriteFile(withdrawalAddressesOutputFile, jsonData, 0o644); err != nil { //nolint:gosec // no strict permissions needed
+			return fmt.Errorf("failed to write withdrawal addresses file: %w", err)
+		}
+
+		logrus.Infof("wrote withdrawal addresses to file: %s", withdrawalAddressesOutputFile)
+	}
+
+	if validatorRangesOutputFile := cmd.String(validatorRangesOutputFlag.Name); validatorRangesOutputFile != "" {
+		yamlData, err := yaml.Marshal(validators.GetValidatorRanges(clValidators, defaultBalance))
+		if err != nil {
+			return fmt.Errorf("failed to serialize validator ranges: %w", err)
+		}
+
+		if err := os.WriteFile(validatorRangesOutputFile, yamlData, 0o644); err != nil { //nolint:gosec // no strict permissions needed
+			return fmt.Errorf("failed to write validator ranges file: %w", err)
+		}
+
+		logrus.Infof("wrote validator ranges to file: %s", validatorRangesOutputFile)
+	}
+
+	if validatorsCSVOutputFile := cmd.String(validatorsCSVOutputFlag.Name); validatorsCSVOutputFile != "" {
+		csvData, err := validators.MarshalValidatorsCSV(clValidators)
+		if err != nil {
+			return fmt.Errorf("failed to serialize validators csv: %w", err)
+		}
+
+		if err := os.WriteFile(validatorsCSVOutputFile, csvData, 0o644); err != nil { //nolint:gosec // no strict permissions needed
+			return fmt.Errorf("failed to write validators csv file: %w", err)
+		}
+
+		logrus.Infof("wrote validators csv to file: %s", validatorsCSVOutputFile)
+	}
+
+	return nil
+}
+
+// writeBuildOutputs writes the execution genesis, effective config and build metadata output files.
+func writeBuildOutputs(cmd *cli.Command, clConfig *config.Config, elGenesis *core.Genesis, state *spec.VersionedBeaconState, clValidators []*validators.Validator) error {
+	if eth1ConfigOutputFile := cmd.String(eth1ConfigOutputFlag.Name); eth1ConfigOutputFile != "" {
+		if err := writeEth1Config(eth1ConfigOutputFile, elGenesis); err != nil {
+			return err
+		}
+	}
+
+	if configOutputFile := cmd.String(configOutputFlag.Name); configOutputFile != "" {
+		configData, err := clConfig.MarshalEffectiveConfig()
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(configOutputFile, configData, 0o644); err != nil { //nolint:gosec // no strict permissions needed
+			return fmt.Errorf("failed to write config file: %w", err)
+		}
+
+		logrus.Infof("wrote effective config to file: %s", configOutputFile)
+	}
+
+	if metadataOutputFile := cmd.String(metadataOutputFlag.Name); metadataOutputFile != "" {
+		if err := writeBuildMetadata(metadataOutputFile, getConfigSourceName(cmd), clConfig, state, clValidators); err != nil {
+			return err
+		}
+
+		logrus.Infof("wrote build metadata to file: %s", metadataOutputFile)
+	}
+
+	return nil
+}
+
+// writeEth1Config writes the execution genesis as JSON file.
+func writeEth1Config(path string, elGenesis *core.Genesis) error {
+	jsonData, err := json.MarshalIndent(elGenesis, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize execution genesis: %w", err)
+	}
+
+	if err := os.WriteFile(path, jsonData, 0o644); err != nil { //nolint:gosec // no strict permissions needed
+		return fmt.Errorf("failed to write execution genesis file: %w", err)
+	}
+
+	logrus.Infof("wrote execution genesis to file: %s", path)
+
+	return nil
+}
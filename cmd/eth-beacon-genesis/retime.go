@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ethpandaops/eth-beacon-genesis/eth1"
+	"github.com/ethpandaops/eth-beacon-genesis/generator"
+	"github.com/ethpandaops/eth-beacon-genesis/utils"
+	"github.com/ethpandaops/eth-beacon-genesis/validators"
+)
+
+var (
+	retimeStateFlag = &cli.StringFlag{
+		Name:     "state",
+		Usage:    "Path or URL to the genesis state (SSZ) to retime",
+		Required: true,
+	}
+	retimeEth1ConfigFlag = &cli.StringFlag{
+		Name:  "eth1-config",
+		Usage: "Path to the execution genesis config (genesis.json) the genesis state was built from, to retime along",
+	}
+	retimeForkVersionsFlag = &cli.BoolFlag{
+		Name:  "update-fork-versions",
+		Usage: "Set the fork versions of the genesis state to the fork versions of the consensus config",
+	}
+
+	retimeCommand = &cli.Command{
+		Name:  "retime",
+		Usage: "Change the genesis time (and optionally the fork versions and execution genesis) of an existing genesis state",
+		Flags: []cli.Flag{
+			retimeStateFlag, retimeEth1ConfigFlag, configFlag, configURLFlag, networkFlag, strictConfigFlag, presetFileFlag, presetDirFlag,
+			configSetFlag, configEnvPrefixFlag, genesisTimeFlag, genesisInFlag, genesisTimeAlignFlag, retimeForkVersionsFlag,
+			stateOutputFlag, jsonOutputFlag, eth1ConfigOutputFlag, quietFlag,
+		},
+		Action:    runRetime,
+		UsageText: "eth-beacon-genesis retime --state genesis.ssz --config config.yaml --genesis-in 10m [--eth1-config genesis.json --eth1-config-output genesis.new.json] --state-output genesis.new.ssz",
+	}
+)
+
+func runRetime(ctx context.Context, cmd *cli.Command) error {
+	eth1Config := cmd.String(retimeEth1ConfigFlag.Name)
+	eth1ConfigOutputFile := cmd.String(eth1ConfigOutputFlag.Name)
+	quiet := cmd.Bool(quietFlag.Name)
+
+	if eth1Config != "" && eth1ConfigOutputFile == "" {
+		return fmt.Errorf("--%v requires --%v for the retimed execution genesis", retimeEth1ConfigFlag.Name, eth1ConfigOutputFlag.Name)
+	}
+
+	if quiet {
+		logrus.SetLevel(logrus.PanicLevel)
+	}
+
+	if !quiet {
+		logrus.Infof("eth-beacon-genesis version: %s", utils.GetBuildVersion())
+	}
+
+	clConfig, err := loadConsensusConfig(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	genesisState, err := validators.LoadBeaconState(cmd.String(retimeStateFlag.Name), clConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load genesis state: %w", err)
+	}
+
+	stateGenesisTime, err := generator.StateGenesisTime(genesisState)
+	if err != nil {
+		return fmt.Errorf("failed to get genesis time: %w", err)
+	}
+
+	logrus.Infof("loaded %v genesis state. genesis time: %v", genesisState.Version, stateGenesisTime)
+
+	genesisTime, err := getGenesisTime(cmd, clConfig, stateGenesisTime)
+	if err != nil {
+		return err
+	}
+
+	options := &generator.RetimeOptions{
+		GenesisTime:        genesisTime,
+		UpdateForkVersions: cmd.Bool(retimeForkVersionsFlag.Name),
+	}
+
+	var elGenesis *core.Genesis
+
+	if eth1Config != "" {
+		elGenesis, err = eth1.LoadEth1GenesisConfig(eth1Config)
+		if err != nil {
+			return fmt.Errorf("failed to load execution genesis: %w", err)
+		}
+
+		options.ExecutionGenesis = elGenesis
+	}
+
+	if err := generator.RetimeGenesis(clConfig, genesisState, options); err != nil {
+		return fmt.Errorf("failed to retime genesis: %w", err)
+	}
+
+	logrus.Infof("successfully retimed genesis state.")
+
+	if elGenesis != nil {
+		if err := writeEth1Config(eth1ConfigOutputFile, elGenesis); err != nil {
+			return err
+		}
+	}
+
+	return writeStateOutputs(cmd, clConfig, genesisState)
+}
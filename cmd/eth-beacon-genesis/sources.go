@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v3"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+	"github.com/ethpandaops/eth-beacon-genesis/validators"
+)
+
+// loadValidatorSources loads the validators of all validator sources given via command line flags.
+func loadValidatorSources(cmd *cli.Command, clConfig *config.Config) ([]*validators.SourceValidators, error) {
+	mnemonicsFile := cmd.String(mnemonicsFileFlag.Name)
+	validatorsFile := cmd.String(validatorsFileFlag.Name)
+	depositDataFiles := cmd.StringSlice(depositDataFlag.Name)
+	keystoresFile := cmd.String(keystoresFileFlag.Name)
+	importValidators := cmd.String(importValidatorsFlag.Name)
+	interopValidators := cmd.Uint(interopValidatorsFlag.Name)
+	interopValidatorsStart := cmd.Uint(interopValidatorsStartFlag.Name)
+	syntheticValidators := cmd.Uint(syntheticValidatorsFlag.Name)
+	interop := cmd.Bool(interopFlag.Name)
+
+	var sources []*validators.SourceValidators
+
+	if mnemonicsFile != "" {
+		vals, err := validators.GenerateValidatorsByMnemonic(mnemonicsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load validators from mnemonics file: %w", err)
+		}
+
+		sources = append(sources, &validators.SourceValidators{
+			Name:       "mnemonics",
+			Generated:  true,
+			Validators: vals,
+		})
+	}
+
+	if validatorsFile != "" {
+		vals, err := validators.LoadValidatorsFromFile(validatorsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load validators from file: %w", err)
+		}
+
+		sources = append(sources, &validators.SourceValidators{
+			Name:       "additional-validators",
+			Validators: vals,
+		})
+	}
+
+	if len(depositDataFiles) > 0 {
+		genesisForkVersion := clConfig.GetBytesOrDefault("GENESIS_FORK_VERSION")
+
+		for _, depositDataFile := range depositDataFiles {
+			vals, err := validators.LoadValidatorsFromDepositData(depositDataFile, phase0.Version(genesisForkVersion))
+			if err != nil {
+				return nil, fmt.Errorf("failed to load validators from deposit data file %v: %w", depositDataFile, err)
+			}
+
+			logrus.Infof("loaded %d validators from deposit data file: %s", len(vals), depositDataFile)
+
+			sources = append(sources, &validators.SourceValidators{
+				Name:       "deposit-data " + depositDataFile,
+				Validators: vals,
+			})
+		}
+	}
+
+	if keystoresFile != "" {
+		vals, err := validators.LoadValidatorsFromKeystores(keystoresFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load validators from keystores: %w", err)
+		}
+
+		sources = append(sources, &validators.SourceValidators{
+			Name:       "keystores",
+			Validators: vals,
+		})
+	}
+
+	if importValidators != "" {
+		filter := &validators.StateValidatorsFilter{
+			Statuses:            cmd.StringSlice(importValidatorsStatusFlag.Name),
+			UseEffectiveBalance: cmd.Bool(importValidatorsEffectiveBalanceFlag.Name),
+		}
+
+		if indexRange := cmd.String(importValidatorsRangeFlag.Name); indexRange != "" {
+			if err := filter.ParseIndexRange(indexRange); err != nil {
+				return nil, err
+			}
+		}
+
+		vals, err := validators.LoadValidatorsFromBeaconState(importValidators, clConfig, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import validators: %w", err)
+		}
+
+		logrus.Infof("imported %d validators from %s", len(vals), importValidators)
+
+		sources = append(sources, &validators.SourceValidators{
+			Name:       "import-validators",
+			Validators: vals,
+		})
+	}
+
+	if interop && (len(sources) > 0 || syntheticValidators > 0) {
+		return nil, fmt.Errorf("interop mode requires interop validators only")
+	}
+
+	if interopValidators > 0 {
+		vals, err := validators.GenerateInteropValidators(interopValidatorsStart, interopValidators)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate interop validators: %w", err)
+		}
+
+		logrus.Infof("generated %d interop validators", len(vals))
+
+		sources = append(sources, &validators.SourceValidators{
+			Name:       "interop",
+			Generated:  true,
+			Validators: vals,
+		})
+	}
+
+	if syntheticValidators > 0 {
+		var withdrawalAddress []byte
+
+		if address := cmd.String(syntheticWithdrawalAddressFlag.Name); address != "" {
+			var err error
+
+			withdrawalAddress, err = hex.DecodeString(strings.TrimPrefix(address, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid synthetic withdrawal address: %w", err)
+			}
+		}
+
+		vals, err := validators.GenerateSyntheticValidators(cmd.Uint(syntheticValidatorsSeedFlag.Name), syntheticValidators, withdrawalAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate synthetic validators: %w", err)
+		}
+
+		logrus.Infof("generated %d synthetic validators", len(vals))
+
+		sources = append(sources, &validators.SourceValidators{
+			Name:       "synthetic",
+			Generated:  true,
+			Validators: vals,
+		})
+	}
+
+	return sources, nil
+}
@@ -4,98 +4,443 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"math/big"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
-
-	"github.com/ethpandaops/eth-beacon-genesis/config/presets"
 )
 
 type Config struct {
-	values map[string]interface{}
-	preset map[string]interface{}
+	values    map[string]interface{}
+	preset    map[string]interface{}
+	overrides []*Override
+	warnings  []string
+}
+
+// LoadOptions are the options for loading a consensus config.
+type LoadOptions struct {
+	// Strict fails on missing required values instead of warning and falling back to defaults.
+	Strict bool
+	// Overrides are KEY=VALUE config overrides, applied in order after the environment overrides.
+	Overrides []string
+	// EnvPrefix enables config overrides from environment variables named <EnvPrefix><KEY> (disabled if empty).
+	EnvPrefix string
+	// PresetFile is a preset file to use instead of the preset referenced by PRESET_BASE.
+	PresetFile string
+	// PresetDir is a directory searched for the preset referenced by PRESET_BASE before the embedded presets.
+	PresetDir string
 }
 
+// Override is a config value overridden via LoadOptions.
+type Override struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// farFutureEpoch is the epoch of unscheduled forks.
+const farFutureEpoch = math.MaxUint64
+
+// LoadConfig loads a consensus config and its preset, see LoadConfigWithOptions.
 func LoadConfig(path string) (*Config, error) {
-	config := &Config{
-		values: make(map[string]interface{}),
-		preset: make(map[string]interface{}),
-	}
+	return LoadConfigWithOptions(path, &LoadOptions{})
+}
 
+// LoadConfigWithOptions loads a consensus config and the preset referenced by PRESET_BASE.
+// Known keys are parsed and checked according to the schema, unknown keys produce warnings.
+func LoadConfigWithOptions(path string, options *LoadOptions) (*Config, error) {
 	// load config from yaml
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("reading config file: %w", err)
 	}
 
-	values := make(map[string]interface{})
-	if err := yaml.Unmarshal(data, &values); err != nil {
-		return nil, fmt.Errorf("parsing yaml: %w", err)
+	return loadConfigData(data, options)
+}
+
+// loadConfigData loads a consensus config from the yaml data of a config file.
+func loadConfigData(data []byte, options *LoadOptions) (*Config, error) {
+	config := newConfig()
+
+	if err := config.parseValues(data, "config", config.values); err != nil {
+		return nil, err
+	}
+
+	if err := config.load(options); err != nil {
+		return nil, err
 	}
 
-	for key, val := range values {
-		switch value := val.(type) {
-		case int:
-			if strings.HasSuffix(key, "_FORK_VERSION") {
-				// convert to big endian byte array
-				bytes := make([]byte, 4)
-				binary.BigEndian.PutUint32(bytes, uint32(value)) //nolint:gosec // ignore overflow
-				config.values[key] = bytes
-			} else {
-				config.values[key] = uint64(value) //nolint:gosec // ignore overflow
-			}
-		case uint64:
-			config.values[key] = value
-		case string:
-			if strings.HasPrefix(value, "0x") {
-				bytes, err := hex.DecodeString(strings.ReplaceAll(value, "0x", ""))
-				if err != nil {
-					return nil, fmt.Errorf("decoding hex: %w", err)
-				}
-
-				config.values[key] = bytes
-			} else if val, err := strconv.ParseUint(value, 10, 64); err == nil {
-				config.values[key] = val
-			} else {
-				config.values[key] = value
-			}
+	return config, nil
+}
+
+func newConfig() *Config {
+	return &Config{
+		values: make(map[string]interface{}),
+		preset: make(map[string]interface{}),
+	}
+}
+
+// load applies the overrides to the parsed config values, loads the preset and checks the resulting values.
+func (c *Config) load(options *LoadOptions) error {
+	if err := c.applyOverrides(options); err != nil {
+		return err
+	}
+
+	if err := c.loadPreset(options); err != nil {
+		return err
+	}
+
+	if err := c.checkRequired(options.Strict); err != nil {
+		return err
+	}
+
+	if err := c.checkSSZSizes(); err != nil {
+		return err
+	}
+
+	return c.checkBlobSchedule()
+}
+
+// Warnings returns the warnings of loading the config (unknown keys and missing values).
+func (c *Config) Warnings() []string {
+	return c.warnings
+}
+
+func (c *Config) warn(format string, args ...interface{}) {
+	warning := fmt.Sprintf(format, args...)
+	c.warnings = append(c.warnings, warning)
+
+	logrus.Warn(warning)
+}
+
+// parseValues parses the values of a config or preset yaml file into the values map.
+func (c *Config) parseValues(data []byte, source string, values map[string]interface{}) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("parsing %v yaml: %w", source, err)
+	}
+
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return fmt.Errorf("parsing %v yaml: expected a mapping on line %v", source, mapping.Line)
+	}
+
+	keyLines := make(map[string]int, len(mapping.Content)/2)
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		keyNode, valueNode := mapping.Content[i], mapping.Content[i+1]
+		key := keyNode.Value
+
+		if line, exists := keyLines[key]; exists {
+			return fmt.Errorf("duplicate %v key %v on line %v and %v", source, key, line, keyNode.Line)
+		}
+
+		keyLines[key] = keyNode.Line
+
+		if err := c.setValue(values, source, key, fmt.Sprintf("on line %v", keyNode.Line), valueNode); err != nil {
+			return err
 		}
 	}
 
-	// load referenced preset
-	presetName, found := config.GetString("PRESET_BASE")
-	if !found || presetName == "" {
-		return nil, fmt.Errorf("preset not found")
+	return nil
+}
+
+// setValue parses the value of a key and stores it in the values map.
+// The location describes where the value comes from in warnings and errors (e.g. "on line 5").
+func (c *Config) setValue(values map[string]interface{}, source, key, location string, valueNode *yaml.Node) error {
+	keyDef, known := LookupKey(key)
+	if !known {
+		if source == specSource {
+			// beacon nodes include constants and client specific values in their spec
+			logrus.Debugf("ignoring unknown spec key %v", key)
+			return nil
+		}
+
+		if suggestion := suggestKey(key); suggestion != "" {
+			c.warn("unknown %v key %v %v (did you mean %v?)", source, key, location, suggestion)
+		} else {
+			c.warn("unknown %v key %v %v", source, key, location)
+		}
+
+		if value := parseUnknownValue(valueNode); value != nil {
+			values[key] = value
+		}
+
+		return nil
 	}
 
-	presetData, err := presets.PresetsFS.ReadFile(presetName + ".yaml")
+	value, err := parseValue(keyDef, valueNode)
 	if err != nil {
-		return nil, fmt.Errorf("preset '%v' not found: %w", presetName, err)
+		return fmt.Errorf("invalid %v value %v %v: %w", source, key, location, err)
 	}
 
-	presets := make(map[string]string)
-	if err := yaml.Unmarshal(presetData, &presets); err != nil {
-		return nil, fmt.Errorf("failed to parse preset yaml: %w", err)
+	if value != nil {
+		values[key] = value
 	}
 
-	for key, value := range presets {
+	return nil
+}
+
+// applyOverrides applies the config overrides from environment variables and the options on top of the config values.
+// Override values are parsed as YAML values, with the same rules as the values of the config file.
+func (c *Config) applyOverrides(options *LoadOptions) error {
+	overrides := []*Override{}
+
+	if options.EnvPrefix != "" {
+		environ := os.Environ()
+		sort.Strings(environ)
+
+		for _, env := range environ {
+			name, value, _ := strings.Cut(env, "=")
+			if !strings.HasPrefix(name, options.EnvPrefix) || name == options.EnvPrefix {
+				continue
+			}
+
+			overrides = append(overrides, &Override{
+				Key:    strings.TrimPrefix(name, options.EnvPrefix),
+				Value:  value,
+				Source: "env " + name,
+			})
+		}
+	}
+
+	for _, override := range options.Overrides {
+		key, value, ok := strings.Cut(override, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid config override '%v' (expected KEY=VALUE)", override)
+		}
+
+		overrides = append(overrides, &Override{
+			Key:    key,
+			Value:  value,
+			Source: "set",
+		})
+	}
+
+	for _, override := range overrides {
+		var root yaml.Node
+		if err := yaml.Unmarshal([]byte(override.Value), &root); err != nil {
+			return fmt.Errorf("invalid config override %v (%v): %w", override.Key, override.Source, err)
+		}
+
+		valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+		if len(root.Content) > 0 {
+			valueNode = root.Content[0]
+		}
+
+		if err := c.setValue(c.values, "config", override.Key, "from "+override.Source, valueNode); err != nil {
+			return err
+		}
+
+		logrus.Infof("config override: %v = %v (%v)", override.Key, override.Value, override.Source)
+	}
+
+	c.overrides = overrides
+
+	return nil
+}
+
+// Overrides returns the applied config overrides in the order they were applied.
+func (c *Config) Overrides() []*Override {
+	return c.overrides
+}
+
+// parseValue parses and checks the value of a known key.
+func parseValue(keyDef *KeyDef, node *yaml.Node) (interface{}, error) {
+	switch keyDef.Type {
+	case TypeList:
+		return parseListValue(keyDef, node)
+	case TypeMap:
+		return parseMapValue(keyDef, node)
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return nil, fmt.Errorf("expected a %v value", keyDef.Type)
+	}
+
+	value := node.Value
+
+	switch keyDef.Type {
+	case TypeUint:
+		if node.Tag != "!!int" && node.Tag != "!!str" {
+			return nil, fmt.Errorf("expected a %v value, got '%v'", keyDef.Type, value)
+		}
+
+		var (
+			number uint64
+			err    error
+		)
+
 		if strings.HasPrefix(value, "0x") {
-			bytes, err := hex.DecodeString(strings.ReplaceAll(value, "0x", ""))
+			number, err = strconv.ParseUint(value[2:], 16, 64)
+		} else {
+			number, err = strconv.ParseUint(value, 10, 64)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("expected a %v value, got '%v'", keyDef.Type, value)
+		}
+
+		if number < keyDef.Min || (keyDef.Max > 0 && number > keyDef.Max) {
+			return nil, fmt.Errorf("value %v out of range (%v)", number, keyDef.rangeString())
+		}
+
+		return number, nil
+	case TypeBigUint:
+		number, ok := new(big.Int).SetString(value, 10)
+		if !ok || number.Sign() < 0 {
+			return nil, fmt.Errorf("expected a %v value, got '%v'", keyDef.Type, value)
+		}
+
+		return number.String(), nil
+	case TypeBytes:
+		var bytes []byte
+
+		switch {
+		case strings.HasPrefix(value, "0x"):
+			decoded, err := hex.DecodeString(value[2:])
 			if err != nil {
 				return nil, fmt.Errorf("decoding hex: %w", err)
 			}
 
-			config.preset[key] = bytes
-		} else if val, err := strconv.ParseUint(value, 10, 64); err == nil {
-			config.preset[key] = val
-		} else {
-			config.preset[key] = value
+			bytes = decoded
+		case node.Tag == "!!int" && keyDef.Length == 4:
+			// legacy decimal fork version, convert to big endian byte array
+			number, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("expected a 0x prefixed hex value, got '%v'", value)
+			}
+
+			bytes = make([]byte, 4)
+			binary.BigEndian.PutUint32(bytes, uint32(number))
+		default:
+			return nil, fmt.Errorf("expected a 0x prefixed hex value, got '%v'", value)
 		}
+
+		if keyDef.Length > 0 && len(bytes) != keyDef.Length {
+			return nil, fmt.Errorf("expected %v bytes, got %v", keyDef.Length, len(bytes))
+		}
+
+		return bytes, nil
+	default:
+		return value, nil
+	}
+}
+
+func (k *KeyDef) rangeString() string {
+	if k.Max > 0 {
+		return fmt.Sprintf("%v - %v", k.Min, k.Max)
 	}
 
-	return config, nil
+	return fmt.Sprintf(">= %v", k.Min)
+}
+
+// parseUnknownValue parses the value of an unknown key by its format (hex bytes, uint64 or string).
+// Lists and mappings are parsed into []interface{} and map[string]interface{} values.
+func parseUnknownValue(node *yaml.Node) interface{} {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return parseUnknownValue(node.Alias)
+	case yaml.SequenceNode:
+		values := make([]interface{}, 0, len(node.Content))
+		for _, itemNode := range node.Content {
+			values = append(values, parseUnknownValue(itemNode))
+		}
+
+		return values
+	case yaml.MappingNode:
+		values := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			values[node.Content[i].Value] = parseUnknownValue(node.Content[i+1])
+		}
+
+		return values
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return nil
+	}
+
+	value := node.Value
+
+	if strings.HasPrefix(value, "0x") {
+		if bytes, err := hex.DecodeString(value[2:]); err == nil {
+			return bytes
+		}
+
+		return value
+	}
+
+	if number, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return number
+	}
+
+	return value
+}
+
+// checkRequired checks that the required values of all scheduled forks are set.
+func (c *Config) checkRequired(strict bool) error {
+	missing := []string{}
+
+	for _, keyDefs := range [][]*KeyDef{configKeys, presetKeys} {
+		for _, keyDef := range keyDefs {
+			if !keyDef.Required || !c.isForkScheduled(keyDef.Fork) {
+				continue
+			}
+
+			if _, ok := c.Get(keyDef.Name); !ok {
+				missing = append(missing, keyDef.Name)
+			}
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if strict {
+		return fmt.Errorf("missing required config values: %v", strings.Join(missing, ", "))
+	}
+
+	c.warn("missing required config values, using defaults: %v", strings.Join(missing, ", "))
+
+	return nil
+}
+
+// isForkScheduled returns true if the fork epoch is set (phase0 is always scheduled).
+func (c *Config) isForkScheduled(fork string) bool {
+	if fork == ForkPhase0 {
+		return true
+	}
+
+	epoch, ok := c.GetUint(strings.ToUpper(fork) + "_FORK_EPOCH")
+
+	return ok && epoch != farFutureEpoch
+}
+
+// GetForkVersion returns the fork version in effect at the given epoch.
+func (c *Config) GetForkVersion(epoch uint64) []byte {
+	for i := len(Forks) - 1; i >= 1; i-- {
+		forkEpoch, ok := c.GetUint(strings.ToUpper(Forks[i]) + "_FORK_EPOCH")
+		if !ok || forkEpoch == farFutureEpoch || epoch < forkEpoch {
+			continue
+		}
+
+		if version, ok := c.GetBytes(strings.ToUpper(Forks[i]) + "_FORK_VERSION"); ok {
+			return version
+		}
+	}
+
+	return c.GetBytesOrDefault("GENESIS_FORK_VERSION")
 }
 
 func (c *Config) Get(key string) (interface{}, bool) {
@@ -134,6 +479,8 @@ func (c *Config) GetUint(key string) (uint64, bool) {
 	return 0, false
 }
 
+// GetUintDefault returns the uint value of a key or the given default. Schema keys use GetUintOrDefault instead,
+// so their defaults are only defined in the schema; this is for spec constants outside the schema.
 func (c *Config) GetUintDefault(key string, defaultVal uint64) uint64 {
 	value, ok := c.GetUint(key)
 	if !ok {
@@ -156,6 +503,7 @@ func (c *Config) GetBytes(key string) ([]byte, bool) {
 	return nil, false
 }
 
+// GetBytesDefault returns the bytes value of a key or the given default (for spec constants outside the schema).
 func (c *Config) GetBytesDefault(key string, defaultVal []byte) []byte {
 	value, ok := c.GetBytes(key)
 	if !ok {
@@ -165,6 +513,36 @@ func (c *Config) GetBytesDefault(key string, defaultVal []byte) []byte {
 	return value
 }
 
+// GetUintOrDefault returns the uint value of a key, or the schema default if the key is not set (0 without default).
+func (c *Config) GetUintOrDefault(key string) uint64 {
+	if value, ok := c.GetUint(key); ok {
+		return value
+	}
+
+	if keyDef, known := LookupKey(key); known {
+		if defaultVal, ok := keyDef.Default.(uint64); ok {
+			return defaultVal
+		}
+	}
+
+	return 0
+}
+
+// GetBytesOrDefault returns the bytes value of a key, or the schema default if the key is not set (nil without default).
+func (c *Config) GetBytesOrDefault(key string) []byte {
+	if value, ok := c.GetBytes(key); ok {
+		return value
+	}
+
+	if keyDef, known := LookupKey(key); known {
+		if defaultVal, ok := keyDef.Default.([]byte); ok {
+			return defaultVal
+		}
+	}
+
+	return nil
+}
+
 func (c *Config) GetSpecs() map[string]interface{} {
 	specs := make(map[string]interface{})
 
@@ -0,0 +1,502 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config/presets"
+)
+
+const testConfig = `
+PRESET_BASE: mainnet
+CONFIG_NAME: testnet
+MIN_GENESIS_TIME: 1606824000
+GENESIS_FORK_VERSION: 0x10000038
+GENESIS_DELAY: 60
+ALTAIR_FORK_VERSION: 0x20000038
+ALTAIR_FORK_EPOCH: 0
+BELLATRIX_FORK_VERSION: 805306424
+BELLATRIX_FORK_EPOCH: 0
+TERMINAL_TOTAL_DIFFICULTY: 58750000000000000000000
+DEPOSIT_CONTRACT_ADDRESS: 0x00000000219ab540356cBB839Cbe05303d7705Fa
+SECONDS_PER_SLOT: "12"
+`
+
+func createTestConfigFile(t *testing.T, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	config, err := LoadConfig(createTestConfigFile(t, testConfig))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if len(config.Warnings()) != 0 {
+		t.Fatalf("unexpected warnings: %v", config.Warnings())
+	}
+
+	if version := config.GetBytesDefault("GENESIS_FORK_VERSION", nil); !bytes.Equal(version, []byte{0x10, 0x00, 0x00, 0x38}) {
+		t.Fatalf("unexpected genesis fork version: 0x%x", version)
+	}
+
+	// decimal fork versions are converted to big endian bytes
+	if version := config.GetBytesDefault("BELLATRIX_FORK_VERSION", nil); !bytes.Equal(version, []byte{0x30, 0x00, 0x00, 0x38}) {
+		t.Fatalf("unexpected bellatrix fork version: 0x%x", version)
+	}
+
+	if ttd, _ := config.GetString("TERMINAL_TOTAL_DIFFICULTY"); ttd != "58750000000000000000000" {
+		t.Fatalf("unexpected terminal total difficulty: %v", ttd)
+	}
+
+	if address := config.GetBytesDefault("DEPOSIT_CONTRACT_ADDRESS", nil); len(address) != 20 {
+		t.Fatalf("unexpected deposit contract address: 0x%x", address)
+	}
+
+	if seconds := config.GetUintDefault("SECONDS_PER_SLOT", 0); seconds != 12 {
+		t.Fatalf("unexpected seconds per slot: %v", seconds)
+	}
+
+	if slots := config.GetUintDefault("SLOTS_PER_EPOCH", 0); slots != 32 {
+		t.Fatalf("unexpected preset slots per epoch: %v", slots)
+	}
+}
+
+func TestLoadConfigUnknownKeys(t *testing.T) {
+	config, err := LoadConfig(createTestConfigFile(t, testConfig+"SECONDS_PER_SLOTS: 6\nCUSTOM_SETTING: 0x1234\n"))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	warnings := strings.Join(config.Warnings(), "\n")
+	if !strings.Contains(warnings, "unknown config key SECONDS_PER_SLOTS on line 14 (did you mean SECONDS_PER_SLOT?)") {
+		t.Fatalf("expected warning with suggestion, got: %v", warnings)
+	}
+
+	if !strings.Contains(warnings, "unknown config key CUSTOM_SETTING on line 15") || strings.Contains(warnings, "did you mean CUSTOM") {
+		t.Fatalf("expected warning for custom key, got: %v", warnings)
+	}
+
+	// unknown keys are still available
+	if value := config.GetBytesDefault("CUSTOM_SETTING", nil); !bytes.Equal(value, []byte{0x12, 0x34}) {
+		t.Fatalf("unexpected custom value: 0x%x", value)
+	}
+}
+
+func TestLoadConfigInvalidValues(t *testing.T) {
+	tests := []struct {
+		value string
+		err   string
+	}{
+		{"ALTAIR_FORK_VERSION: 0x200000", "invalid config value ALTAIR_FORK_VERSION on line 7: expected 4 bytes, got 3"},
+		{"SECONDS_PER_SLOT: 0", "invalid config value SECONDS_PER_SLOT on line 13: value 0 out of range (>= 1)"},
+		{"PROPOSER_SCORE_BOOST: 140", "value 140 out of range (0 - 100)"},
+		{"ETH1_FOLLOW_DISTANCE: -1", "expected a uint64 value, got '-1'"},
+		{"ETH1_FOLLOW_DISTANCE: 1.5", "expected a uint64 value, got '1.5'"},
+		{"TERMINAL_BLOCK_HASH: 1234", "expected a 0x prefixed hex value, got '1234'"},
+		{"TERMINAL_BLOCK_HASH: 0xzz", "decoding hex"},
+		{"BLOB_SCHEDULE: 5", "expected a list"},
+		{"SECONDS_PER_SLOT: 6", "duplicate config key SECONDS_PER_SLOT on line 13 and 14"},
+	}
+
+	for _, test := range tests {
+		data := testConfig + test.value + "\n"
+		if strings.HasPrefix(test.value, "ALTAIR_FORK_VERSION") {
+			data = strings.Replace(testConfig, "ALTAIR_FORK_VERSION: 0x20000038", test.value, 1)
+		} else if strings.HasPrefix(test.value, "SECONDS_PER_SLOT: 0") {
+			data = strings.Replace(testConfig, `SECONDS_PER_SLOT: "12"`, test.value, 1)
+		}
+
+		_, err := LoadConfig(createTestConfigFile(t, data))
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("%v: expected error containing %q, got %v", test.value, test.err, err)
+		}
+	}
+}
+
+func TestLoadConfigStrict(t *testing.T) {
+	data := strings.Replace(testConfig, "GENESIS_DELAY: 60\n", "", 1)
+	data = strings.Replace(data, "BELLATRIX_FORK_VERSION: 805306424\n", "", 1)
+
+	config, err := LoadConfig(createTestConfigFile(t, data))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if len(config.Warnings()) != 1 || !strings.Contains(config.Warnings()[0], "GENESIS_DELAY, BELLATRIX_FORK_VERSION") {
+		t.Fatalf("expected missing values warning, got: %v", config.Warnings())
+	}
+
+	_, err = LoadConfigWithOptions(createTestConfigFile(t, data), &LoadOptions{Strict: true})
+	if err == nil || err.Error() != "missing required config values: GENESIS_DELAY, BELLATRIX_FORK_VERSION" {
+		t.Fatalf("expected missing values error, got: %v", err)
+	}
+
+	// versions of unscheduled forks are not required
+	data = strings.Replace(data, "BELLATRIX_FORK_EPOCH: 0\n", "BELLATRIX_FORK_EPOCH: 18446744073709551615\n", 1)
+	data += "GENESIS_DELAY: 60\n"
+
+	if _, err := LoadConfigWithOptions(createTestConfigFile(t, data), &LoadOptions{Strict: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLoadConfigOverrides(t *testing.T) {
+	t.Setenv("TEST_CONFIG_GENESIS_DELAY", "120")
+	t.Setenv("TEST_CONFIG_SECONDS_PER_SLOT", "6")
+
+	config, err := LoadConfigWithOptions(createTestConfigFile(t, testConfig), &LoadOptions{
+		Overrides: []string{"SECONDS_PER_SLOT=4", "ALTAIR_FORK_VERSION=0x20000039", "SECONDS_PER_SLOTS=2"},
+		EnvPrefix: "TEST_CONFIG_",
+	})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if value := config.GetUintDefault("GENESIS_DELAY", 0); value != 120 {
+		t.Fatalf("expected GENESIS_DELAY 120 from env, got %v", value)
+	}
+
+	// --set overrides are applied after environment overrides
+	if value := config.GetUintDefault("SECONDS_PER_SLOT", 0); value != 4 {
+		t.Fatalf("expected SECONDS_PER_SLOT 4, got %v", value)
+	}
+
+	if value := config.GetBytesDefault("ALTAIR_FORK_VERSION", nil); !bytes.Equal(value, []byte{0x20, 0x00, 0x00, 0x39}) {
+		t.Fatalf("unexpected ALTAIR_FORK_VERSION: %x", value)
+	}
+
+	if len(config.Overrides()) != 5 || config.Overrides()[0].Source != "env TEST_CONFIG_GENESIS_DELAY" {
+		t.Fatalf("unexpected overrides: %v", config.Overrides())
+	}
+
+	if len(config.Warnings()) != 1 || !strings.Contains(config.Warnings()[0], "SECONDS_PER_SLOTS from set (did you mean SECONDS_PER_SLOT?)") {
+		t.Fatalf("expected unknown key warning, got: %v", config.Warnings())
+	}
+
+	tests := []struct {
+		override string
+		err      string
+	}{
+		{"GENESIS_DELAY", "expected KEY=VALUE"},
+		{"GENESIS_DELAY=abc", "invalid config value GENESIS_DELAY from set"},
+		{"GENESIS_FORK_VERSION=0x1000", "invalid config value GENESIS_FORK_VERSION from set"},
+	}
+
+	for _, test := range tests {
+		_, err := LoadConfigWithOptions(createTestConfigFile(t, testConfig), &LoadOptions{Overrides: []string{test.override}})
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("%v: expected error containing %q, got %v", test.override, test.err, err)
+		}
+	}
+}
+
+func TestLoadConfigPresets(t *testing.T) {
+	data := strings.Replace(testConfig, "PRESET_BASE: mainnet", "PRESET_BASE: gnosis", 1)
+
+	config, err := LoadConfig(createTestConfigFile(t, data))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if slots := config.GetUintDefault("SLOTS_PER_EPOCH", 0); slots != 16 {
+		t.Fatalf("expected gnosis SLOTS_PER_EPOCH 16, got %v", slots)
+	}
+
+	// preset directory with one file per fork
+	presetDir := t.TempDir()
+	mainnetPreset, _ := os.ReadFile("presets/mainnet.yaml")
+
+	if err := os.Mkdir(filepath.Join(presetDir, "custom"), 0o700); err != nil {
+		t.Fatalf("failed to create preset dir: %v", err)
+	}
+
+	phase0Preset, otherPreset, _ := strings.Cut(string(mainnetPreset), "# Mainnet preset - Altair")
+	phase0Preset = strings.Replace(phase0Preset, "SLOTS_PER_EPOCH: 32", "SLOTS_PER_EPOCH: 4", 1)
+
+	for name, content := range map[string]string{"phase0.yaml": phase0Preset, "altair.yaml": otherPreset} {
+		if err := os.WriteFile(filepath.Join(presetDir, "custom", name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write preset: %v", err)
+		}
+	}
+
+	data = strings.Replace(testConfig, "PRESET_BASE: mainnet", "PRESET_BASE: custom", 1)
+
+	config, err = LoadConfigWithOptions(createTestConfigFile(t, data), &LoadOptions{PresetDir: presetDir})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if slots := config.GetUintDefault("SLOTS_PER_EPOCH", 0); slots != 4 {
+		t.Fatalf("expected custom SLOTS_PER_EPOCH 4, got %v", slots)
+	}
+
+	if err := os.WriteFile(filepath.Join(presetDir, "custom", "extra.yaml"), []byte("SLOTS_PER_EPOCH: 8\n"), 0o600); err != nil {
+		t.Fatalf("failed to write preset: %v", err)
+	}
+
+	_, err = LoadConfigWithOptions(createTestConfigFile(t, data), &LoadOptions{PresetDir: presetDir})
+	if err == nil || !strings.Contains(err.Error(), "duplicate preset key SLOTS_PER_EPOCH") {
+		t.Fatalf("expected duplicate preset key error, got: %v", err)
+	}
+
+	// preset file instead of PRESET_BASE
+	presetFile := filepath.Join(t.TempDir(), "preset.yaml")
+	if err := os.WriteFile(presetFile, []byte(strings.Replace(string(mainnetPreset), "SLOTS_PER_EPOCH: 32", "SLOTS_PER_EPOCH: 6", 1)), 0o600); err != nil {
+		t.Fatalf("failed to write preset: %v", err)
+	}
+
+	config, err = LoadConfigWithOptions(createTestConfigFile(t, data), &LoadOptions{PresetFile: presetFile})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if slots := config.GetUintDefault("SLOTS_PER_EPOCH", 0); slots != 6 {
+		t.Fatalf("expected SLOTS_PER_EPOCH 6 from preset file, got %v", slots)
+	}
+}
+
+func TestLoadConfigPresetOverrides(t *testing.T) {
+	config, err := LoadConfig(createTestConfigFile(t, testConfig+"SLOTS_PER_EPOCH: 8\n"))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if slots := config.GetUintDefault("SLOTS_PER_EPOCH", 0); slots != 8 {
+		t.Fatalf("expected SLOTS_PER_EPOCH 8 from config, got %v", slots)
+	}
+
+	tests := []struct {
+		values string
+		err    string
+	}{
+		{"MAX_BLOB_COMMITMENTS_PER_BLOCK: 32\n", "invalid KZG_COMMITMENT_INCLUSION_PROOF_DEPTH 17: must be 10"},
+		{"MAX_BLOB_COMMITMENTS_PER_BLOCK: 32\nKZG_COMMITMENT_INCLUSION_PROOF_DEPTH: 10\nMAX_BLOBS_PER_BLOCK: 64\n", "invalid MAX_BLOBS_PER_BLOCK 64"},
+		{"BYTES_PER_LOGS_BLOOM: 128\n", "unsupported BYTES_PER_LOGS_BLOOM 128"},
+		{"SYNC_COMMITTEE_SIZE: 100\n", "invalid SYNC_COMMITTEE_SIZE 100"},
+	}
+
+	for _, test := range tests {
+		_, err := LoadConfig(createTestConfigFile(t, testConfig+test.values))
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("%q: expected error containing %q, got %v", test.values, test.err, err)
+		}
+	}
+
+	if _, err := LoadConfig(createTestConfigFile(t, testConfig+"MAX_BLOB_COMMITMENTS_PER_BLOCK: 32\nKZG_COMMITMENT_INCLUSION_PROOF_DEPTH: 10\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLoadConfigStructuredValues(t *testing.T) {
+	data := testConfig + `ELECTRA_FORK_EPOCH: 10
+MAX_BLOBS_PER_BLOCK_ELECTRA: 9
+BLOB_SCHEDULE:
+  - EPOCH: 30
+    MAX_BLOBS_PER_BLOCK: 21
+  - EPOCH: 20
+    MAX_BLOBS_PER_BLOCK: 15
+CUSTOM_SETTINGS:
+  LIMITS: [1, 2]
+  NAME: test
+`
+
+	config, err := LoadConfig(createTestConfigFile(t, data))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	schedule := config.GetBlobSchedule()
+	if len(schedule) != 2 || schedule[0].Epoch != 20 || schedule[1].MaxBlobsPerBlock != 21 {
+		t.Fatalf("unexpected blob schedule: %v", schedule)
+	}
+
+	for epoch, maxBlobs := range map[uint64]uint64{0: 9, 19: 9, 20: 15, 29: 15, 30: 21, 100: 21} {
+		if params := config.GetBlobParameters(epoch); params.MaxBlobsPerBlock != maxBlobs {
+			t.Fatalf("epoch %v: expected %v max blobs, got %v", epoch, maxBlobs, params.MaxBlobsPerBlock)
+		}
+	}
+
+	custom, ok := config.GetMap("CUSTOM_SETTINGS")
+	if !ok || custom["NAME"] != "test" {
+		t.Fatalf("unexpected CUSTOM_SETTINGS: %v", custom)
+	}
+
+	if limits, ok := custom["LIMITS"].([]interface{}); !ok || len(limits) != 2 || limits[1] != uint64(2) {
+		t.Fatalf("unexpected CUSTOM_SETTINGS.LIMITS: %v", custom["LIMITS"])
+	}
+
+	tests := []struct {
+		value string
+		err   string
+	}{
+		{"{EPOCH: 1}", "expected a list"},
+		{"[{EPOCH: 1}]", "entry 0: missing MAX_BLOBS_PER_BLOCK"},
+		{"[{EPOCH: 1, MAX_BLOBS_PER_BLOCK: 6, MAX_BLOBS: 6}]", "entry 0: unknown field MAX_BLOBS"},
+		{"[{EPOCH: 1, MAX_BLOBS_PER_BLOCK: abc}]", "entry 0: invalid MAX_BLOBS_PER_BLOCK"},
+		{"[{EPOCH: 1, MAX_BLOBS_PER_BLOCK: 6}, {EPOCH: 1, MAX_BLOBS_PER_BLOCK: 9}]", "duplicate epoch 1"},
+		{"[{EPOCH: 1, MAX_BLOBS_PER_BLOCK: 5000}]", "exceeds MAX_BLOB_COMMITMENTS_PER_BLOCK"},
+	}
+
+	for _, test := range tests {
+		_, err := LoadConfigWithOptions(createTestConfigFile(t, testConfig), &LoadOptions{
+			Overrides: []string{"BLOB_SCHEDULE=" + test.value},
+		})
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("%v: expected error containing %q, got %v", test.value, test.err, err)
+		}
+	}
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	data := strings.Replace(testConfig, "GENESIS_DELAY: 60\n", "", 1) + "BLOB_SCHEDULE:\n  - MAX_BLOBS_PER_BLOCK: 15\n    EPOCH: 20\n"
+
+	config, err := LoadConfigWithOptions(createTestConfigFile(t, data), &LoadOptions{
+		Overrides: []string{"SECONDS_PER_SLOT=6"},
+	})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	sources := map[string]string{}
+	for _, value := range config.EffectiveValues() {
+		sources[value.Key] = value.Source + " " + value.FormatValue()
+	}
+
+	expectedSources := map[string]string{
+		"GENESIS_DELAY":             "default 604800",
+		"SECONDS_PER_SLOT":          "override (set) 6",
+		"BELLATRIX_FORK_VERSION":    "config 0x30000038",
+		"SLOTS_PER_EPOCH":           "preset 32",
+		"TERMINAL_TOTAL_DIFFICULTY": "config 58750000000000000000000",
+		"BLOB_SCHEDULE":             "config [{EPOCH: 20, MAX_BLOBS_PER_BLOCK: 15}]",
+	}
+
+	for key, expected := range expectedSources {
+		if sources[key] != expected {
+			t.Fatalf("%v: expected %q, got %q", key, expected, sources[key])
+		}
+	}
+
+	// electra is not scheduled, so its defaults are not included
+	if _, ok := sources["MAX_BLOBS_PER_BLOCK_ELECTRA"]; ok {
+		t.Fatalf("unexpected default for unscheduled fork")
+	}
+
+	output, err := config.MarshalEffectiveConfig()
+	if err != nil {
+		t.Fatalf("failed to serialize config: %v", err)
+	}
+
+	for _, line := range []string{
+		"PRESET_BASE: 'mainnet'\n",
+		"BELLATRIX_FORK_VERSION: 0x30000038\n",
+		"GENESIS_DELAY: 604800\n",
+		"TERMINAL_TOTAL_DIFFICULTY: 58750000000000000000000\n",
+		"BLOB_SCHEDULE:\n  - EPOCH: 20\n    MAX_BLOBS_PER_BLOCK: 15\n",
+	} {
+		if !strings.Contains(string(output), line) {
+			t.Fatalf("expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+
+	if strings.Contains(string(output), "SLOTS_PER_EPOCH") {
+		t.Fatalf("expected no preset values in output, got:\n%s", output)
+	}
+
+	// the effective config loads to the same values
+	reloaded, err := LoadConfigWithOptions(createTestConfigFile(t, string(output)), &LoadOptions{Strict: true})
+	if err != nil {
+		t.Fatalf("failed to load effective config: %v", err)
+	}
+
+	reloadedOutput, err := reloaded.MarshalEffectiveConfig()
+	if err != nil {
+		t.Fatalf("failed to serialize config: %v", err)
+	}
+
+	if !bytes.Equal(output, reloadedOutput) {
+		t.Fatalf("effective config changed after reload:\n%s\n%s", output, reloadedOutput)
+	}
+}
+
+func TestSchemaDefaults(t *testing.T) {
+	presetData, err := presets.PresetsFS.ReadFile("mainnet.yaml")
+	if err != nil {
+		t.Fatalf("failed to read mainnet preset: %v", err)
+	}
+
+	mainnet := newConfig()
+	if err := mainnet.parseValues(presetData, "preset", mainnet.preset); err != nil {
+		t.Fatalf("failed to parse mainnet preset: %v", err)
+	}
+
+	// the defaults of preset keys must not drift from the mainnet preset
+	for _, keyDef := range presetKeys {
+		if keyDef.Default == nil {
+			continue
+		}
+
+		if value, ok := mainnet.preset[keyDef.Name]; !ok || fmt.Sprint(value) != fmt.Sprint(keyDef.Default) {
+			t.Fatalf("%v: default %v does not match the mainnet preset value %v", keyDef.Name, keyDef.Default, value)
+		}
+	}
+}
+
+func TestEffectiveConfigPresetDefaults(t *testing.T) {
+	presetData, err := presets.PresetsFS.ReadFile("mainnet.yaml")
+	if err != nil {
+		t.Fatalf("failed to read mainnet preset: %v", err)
+	}
+
+	presetFile := filepath.Join(t.TempDir(), "preset.yaml")
+	if err := os.WriteFile(presetFile, []byte(strings.Replace(string(presetData), "MAX_EFFECTIVE_BALANCE: 32000000000\n", "", 1)), 0o600); err != nil {
+		t.Fatalf("failed to write preset: %v", err)
+	}
+
+	data := strings.Replace(testConfig, "SECONDS_PER_SLOT: \"12\"\n", "", 1)
+
+	config, err := LoadConfigWithOptions(createTestConfigFile(t, data), &LoadOptions{PresetFile: presetFile})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if len(config.Warnings()) != 1 || !strings.Contains(config.Warnings()[0], "MAX_EFFECTIVE_BALANCE") {
+		t.Fatalf("expected missing preset value warning, got: %v", config.Warnings())
+	}
+
+	if value := config.GetUintOrDefault("MAX_EFFECTIVE_BALANCE"); value != 32_000_000_000 {
+		t.Fatalf("unexpected MAX_EFFECTIVE_BALANCE %v", value)
+	}
+
+	sources := map[string]string{}
+	for _, value := range config.EffectiveValues() {
+		sources[value.Key] = value.Source + " " + value.FormatValue()
+	}
+
+	if sources["MAX_EFFECTIVE_BALANCE"] != "default 32000000000" || sources["SECONDS_PER_SLOT"] != "default 12" {
+		t.Fatalf("unexpected sources: %v, %v", sources["MAX_EFFECTIVE_BALANCE"], sources["SECONDS_PER_SLOT"])
+	}
+
+	output, err := config.MarshalEffectiveConfig()
+	if err != nil {
+		t.Fatalf("failed to serialize config: %v", err)
+	}
+
+	for _, line := range []string{"MAX_EFFECTIVE_BALANCE: 32000000000\n", "SECONDS_PER_SLOT: 12\n"} {
+		if !strings.Contains(string(output), line) {
+			t.Fatalf("expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config/networks"
+)
+
+// LoadNetworkConfig loads the embedded canonical config of a public network (see EmbeddedNetworks)
+// with the same options as config files.
+func LoadNetworkConfig(name string, options *LoadOptions) (*Config, error) {
+	data, err := networks.NetworksFS.ReadFile(name + ".yaml")
+	if err != nil || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("network '%v' not found (embedded networks: %v)", name, strings.Join(EmbeddedNetworks(), ", "))
+	}
+
+	return loadConfigData(data, options)
+}
+
+// EmbeddedNetworks returns the names of the networks with an embedded config.
+func EmbeddedNetworks() []string {
+	names := []string{}
+
+	entries, err := networks.NetworksFS.ReadDir(".")
+	if err != nil {
+		return names
+	}
+
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".yaml"); ok {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	beaconparams "github.com/ethereum/go-ethereum/beacon/params"
+)
+
+func TestLoadNetworkConfig(t *testing.T) {
+	networks := EmbeddedNetworks()
+	if strings.Join(networks, ",") != "holesky,hoodi,mainnet,minimal,sepolia" {
+		t.Fatalf("unexpected embedded networks: %v", networks)
+	}
+
+	for _, network := range networks {
+		config, err := LoadNetworkConfig(network, &LoadOptions{Strict: true})
+		if err != nil {
+			t.Fatalf("%v: failed to load config: %v", network, err)
+		}
+
+		if len(config.Warnings()) != 0 {
+			t.Fatalf("%v: unexpected warnings: %v", network, config.Warnings())
+		}
+
+		if name, _ := config.GetString("CONFIG_NAME"); name != network {
+			t.Fatalf("%v: unexpected CONFIG_NAME %v", network, name)
+		}
+	}
+
+	config, err := LoadNetworkConfig("mainnet", &LoadOptions{Overrides: []string{"GENESIS_DELAY=60"}})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if delay := config.GetUintDefault("GENESIS_DELAY", 0); delay != 60 {
+		t.Fatalf("expected overridden GENESIS_DELAY 60, got %v", delay)
+	}
+
+	for _, name := range []string{"kiln", "../presets/mainnet"} {
+		if _, err := LoadNetworkConfig(name, &LoadOptions{}); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("%v: expected not found error, got: %v", name, err)
+		}
+	}
+}
+
+// TestNetworkConfigForks checks the fork schedules of the embedded network configs against
+// the beacon chain configs of the go-ethereum light client, which are maintained independently.
+func TestNetworkConfigForks(t *testing.T) {
+	tests := []struct {
+		network     string
+		chainConfig *beaconparams.ChainConfig
+		// genesisTime is 0 for networks with a genesis time not derived from MIN_GENESIS_TIME + GENESIS_DELAY
+		genesisTime uint64
+	}{
+		{"mainnet", beaconparams.MainnetLightConfig, 0},
+		{"sepolia", beaconparams.SepoliaLightConfig, 1655733600},
+		{"holesky", beaconparams.HoleskyLightConfig, 1695902400},
+		// the go-ethereum light client config holds the execution genesis time for hoodi
+		{"hoodi", beaconparams.HoodiLightConfig, 0},
+	}
+
+	for _, test := range tests {
+		config, err := LoadNetworkConfig(test.network, &LoadOptions{})
+		if err != nil {
+			t.Fatalf("%v: failed to load config: %v", test.network, err)
+		}
+
+		for _, fork := range test.chainConfig.Forks {
+			// forks scheduled after the release of the go-ethereum version are not comparable
+			if fork.Epoch == farFutureEpoch {
+				continue
+			}
+
+			versionKey, epochKey := fork.Name+"_FORK_VERSION", fork.Name+"_FORK_EPOCH"
+			epoch := config.GetUintDefault(epochKey, farFutureEpoch)
+
+			if fork.Name == "GENESIS" {
+				epoch = 0
+			}
+
+			if version, _ := config.GetBytes(versionKey); !bytes.Equal(version, fork.Version) || epoch != fork.Epoch {
+				t.Fatalf("%v: unexpected %v fork: version 0x%x epoch %v, expected version 0x%x epoch %v", test.network, fork.Name, version, epoch, fork.Version, fork.Epoch)
+			}
+		}
+
+		genesisTime := config.GetUintDefault("MIN_GENESIS_TIME", 0) + config.GetUintDefault("GENESIS_DELAY", 0)
+		if test.genesisTime != 0 && (genesisTime != test.genesisTime || genesisTime != test.chainConfig.GenesisTime) {
+			t.Fatalf("%v: unexpected genesis time %v, expected %v", test.network, genesisTime, test.genesisTime)
+		}
+	}
+}
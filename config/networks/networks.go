@@ -0,0 +1,10 @@
+package networks
+
+import (
+	"embed"
+)
+
+// network configs
+//
+//go:embed *.yaml
+var NetworksFS embed.FS
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sources of effective values
+const (
+	SourceConfig   = "config"
+	SourcePreset   = "preset"
+	SourceDefault  = "default"
+	SourceOverride = "override"
+)
+
+// EffectiveValue is a config or preset value with its provenance.
+type EffectiveValue struct {
+	Key   string
+	Value interface{}
+	// Source is the provenance of the value (config, preset, default or override with the override source).
+	Source string
+	keyDef *KeyDef
+}
+
+// EffectiveValues returns all effective config and preset values with their provenance,
+// including the defaults the builders fall back to for unset keys of scheduled forks.
+// Known keys are ordered as in the schema, followed by the unknown keys sorted by name.
+func (c *Config) EffectiveValues() []*EffectiveValue {
+	overrideSources := make(map[string]string, len(c.overrides))
+	for _, override := range c.overrides {
+		overrideSources[override.Key] = fmt.Sprintf("%v (%v)", SourceOverride, override.Source)
+	}
+
+	effectiveValues := []*EffectiveValue{}
+
+	addValue := func(key string, keyDef *KeyDef) {
+		effectiveValue := &EffectiveValue{
+			Key:    key,
+			keyDef: keyDef,
+		}
+
+		if value, ok := c.values[key]; ok {
+			effectiveValue.Value = value
+			effectiveValue.Source = SourceConfig
+
+			if source, ok := overrideSources[key]; ok {
+				effectiveValue.Source = source
+			}
+		} else if value, ok := c.preset[key]; ok {
+			effectiveValue.Value = value
+			effectiveValue.Source = SourcePreset
+		} else if keyDef != nil && keyDef.Default != nil && c.isForkScheduled(keyDef.Fork) {
+			effectiveValue.Value = keyDef.Default
+			effectiveValue.Source = SourceDefault
+		} else {
+			return
+		}
+
+		effectiveValues = append(effectiveValues, effectiveValue)
+	}
+
+	for _, keyDefs := range [][]*KeyDef{configKeys, presetKeys} {
+		for _, keyDef := range keyDefs {
+			addValue(keyDef.Name, keyDef)
+		}
+	}
+
+	unknownKeys := []string{}
+	unknownKeySet := map[string]bool{}
+
+	for _, values := range []map[string]interface{}{c.values, c.preset} {
+		for key := range values {
+			if _, known := LookupKey(key); !known && !unknownKeySet[key] {
+				unknownKeySet[key] = true
+				unknownKeys = append(unknownKeys, key)
+			}
+		}
+	}
+
+	sort.Strings(unknownKeys)
+
+	for _, key := range unknownKeys {
+		addValue(key, nil)
+	}
+
+	return effectiveValues
+}
+
+// FormatValue returns the value in canonical formatting (lists and mappings in YAML flow style).
+func (v *EffectiveValue) FormatValue() string {
+	node := valueNode(v.Value, v.keyDef)
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+
+	node.Style = yaml.FlowStyle
+
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return fmt.Sprint(v.Value)
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// MarshalEffectiveConfig serializes the effective config as YAML: all config values (including overrides)
+// and the defaults the builders fall back to, in canonical formatting and ordering.
+// Values from the preset are not included, as clients load them from the preset referenced by PRESET_BASE.
+func (c *Config) MarshalEffectiveConfig() ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, effectiveValue := range c.EffectiveValues() {
+		if effectiveValue.Source == SourcePreset {
+			continue
+		}
+
+		root.Content = append(root.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: effectiveValue.Key},
+			valueNode(effectiveValue.Value, effectiveValue.keyDef),
+		)
+	}
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(root); err != nil {
+		return nil, fmt.Errorf("failed to serialize config: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to serialize config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// valueNode converts a value to a YAML node in canonical formatting: integers and big integers unquoted,
+// bytes as 0x prefixed hex, strings single quoted and mapping fields in schema order.
+func valueNode(value interface{}, keyDef *KeyDef) *yaml.Node {
+	switch v := value.(type) {
+	case uint64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: strconv.FormatUint(v, 10)}
+	case []byte:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: "0x" + hex.EncodeToString(v)}
+	case string:
+		if keyDef != nil && keyDef.Type == TypeBigUint {
+			return &yaml.Node{Kind: yaml.ScalarNode, Value: v}
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v, Style: yaml.SingleQuotedStyle}
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range v {
+			node.Content = append(node.Content, valueNode(item, keyDef))
+		}
+
+		return node
+	case map[string]interface{}:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+
+		fieldKeys := []string{}
+		fieldDefs := map[string]*KeyDef{}
+
+		if keyDef != nil {
+			for _, fieldDef := range keyDef.Fields {
+				if _, ok := v[fieldDef.Name]; ok {
+					fieldKeys = append(fieldKeys, fieldDef.Name)
+					fieldDefs[fieldDef.Name] = fieldDef
+				}
+			}
+		}
+
+		otherKeys := []string{}
+
+		for key := range v {
+			if fieldDefs[key] == nil {
+				otherKeys = append(otherKeys, key)
+			}
+		}
+
+		sort.Strings(otherKeys)
+
+		for _, key := range append(fieldKeys, otherKeys...) {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+				valueNode(v[key], fieldDefs[key]),
+			)
+		}
+
+		return node
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v)}
+	}
+}
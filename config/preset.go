@@ -0,0 +1,201 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config/presets"
+)
+
+// fixedSSZSizes are preset values with a fixed size in the SSZ types, which cannot be changed by a preset.
+var fixedSSZSizes = []struct {
+	key  string
+	size uint64
+}{
+	{"BYTES_PER_LOGS_BLOOM", 256},
+	{"FIELD_ELEMENTS_PER_BLOB", 4096},
+}
+
+// loadPreset loads the preset values from the preset file, or the preset referenced by PRESET_BASE
+// from the preset directory or the embedded presets.
+func (c *Config) loadPreset(options *LoadOptions) error {
+	presetFiles := map[string][]byte{}
+
+	if options.PresetFile != "" {
+		data, err := os.ReadFile(options.PresetFile)
+		if err != nil {
+			return fmt.Errorf("reading preset file: %w", err)
+		}
+
+		presetFiles[options.PresetFile] = data
+	} else {
+		presetName, found := c.GetString("PRESET_BASE")
+		if !found || presetName == "" {
+			return fmt.Errorf("preset not found")
+		}
+
+		if presetName == "." || presetName == ".." || strings.ContainsAny(presetName, `/\`) {
+			return fmt.Errorf("invalid preset name '%v'", presetName)
+		}
+
+		files, err := readPreset(presetName, options.PresetDir)
+		if err != nil {
+			return err
+		}
+
+		presetFiles = files
+	}
+
+	fileNames := make([]string, 0, len(presetFiles))
+	for fileName := range presetFiles {
+		fileNames = append(fileNames, fileName)
+	}
+
+	sort.Strings(fileNames)
+
+	// presets split into one file per fork must not define a key twice
+	keyFiles := map[string]string{}
+
+	for _, fileName := range fileNames {
+		values := map[string]interface{}{}
+		if err := c.parseValues(presetFiles[fileName], "preset", values); err != nil {
+			return fmt.Errorf("%v: %w", fileName, err)
+		}
+
+		for key, value := range values {
+			if otherFile, exists := keyFiles[key]; exists {
+				return fmt.Errorf("duplicate preset key %v in %v and %v", key, otherFile, fileName)
+			}
+
+			keyFiles[key] = fileName
+			c.preset[key] = value
+		}
+
+		logrus.Debugf("loaded preset file: %v", fileName)
+	}
+
+	c.logPresetOverrides()
+
+	return nil
+}
+
+// readPreset reads the files of a named preset. The preset directory may contain the preset as single file
+// (<name>.yaml) or as directory with one file per fork (<name>/*.yaml, as in the consensus-specs repository).
+func readPreset(name, presetDir string) (map[string][]byte, error) {
+	if presetDir != "" {
+		presetPath := filepath.Join(presetDir, name+".yaml")
+		if data, err := os.ReadFile(presetPath); err == nil {
+			return map[string][]byte{presetPath: data}, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("reading preset file: %w", err)
+		}
+
+		presetPaths, err := filepath.Glob(filepath.Join(presetDir, name, "*.yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("reading preset directory: %w", err)
+		}
+
+		if len(presetPaths) > 0 {
+			files := make(map[string][]byte, len(presetPaths))
+
+			for _, presetPath := range presetPaths {
+				data, err := os.ReadFile(presetPath)
+				if err != nil {
+					return nil, fmt.Errorf("reading preset file: %w", err)
+				}
+
+				files[presetPath] = data
+			}
+
+			return files, nil
+		}
+	}
+
+	data, err := presets.PresetsFS.ReadFile(name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("preset '%v' not found (embedded presets: %v): %w", name, strings.Join(EmbeddedPresets(), ", "), err)
+	}
+
+	return map[string][]byte{name + ".yaml": data}, nil
+}
+
+// EmbeddedPresets returns the names of the embedded presets.
+func EmbeddedPresets() []string {
+	names := []string{}
+
+	entries, err := presets.PresetsFS.ReadDir(".")
+	if err != nil {
+		return names
+	}
+
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".yaml"); ok {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// logPresetOverrides logs the preset values, which are overridden by the config.
+func (c *Config) logPresetOverrides() {
+	keys := make([]string, 0)
+
+	for key := range c.values {
+		if keyDef, known := LookupKey(key); known && keyDef.Preset {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		presetValue, ok := c.preset[key]
+		if !ok {
+			logrus.Infof("config sets preset value %v: %v", key, c.values[key])
+		} else if fmt.Sprint(presetValue) != fmt.Sprint(c.values[key]) {
+			logrus.Infof("config overrides preset value %v: %v (preset: %v)", key, c.values[key], presetValue)
+		}
+	}
+}
+
+// checkSSZSizes checks that the preset values defining SSZ type sizes are consistent with each other
+// and with the sizes that are fixed in the SSZ types.
+func (c *Config) checkSSZSizes() error {
+	for _, fixedSize := range fixedSSZSizes {
+		if size, ok := c.GetUint(fixedSize.key); ok && size != fixedSize.size {
+			return fmt.Errorf("unsupported %v %v: the size is fixed to %v in the SSZ types", fixedSize.key, size, fixedSize.size)
+		}
+	}
+
+	if size, ok := c.GetUint("SYNC_COMMITTEE_SIZE"); ok && size%8 != 0 {
+		return fmt.Errorf("invalid SYNC_COMMITTEE_SIZE %v: must be a multiple of 8 (size of the sync committee bitvector)", size)
+	}
+
+	maxCommitments, ok := c.GetUint("MAX_BLOB_COMMITMENTS_PER_BLOCK")
+	if !ok {
+		return nil
+	}
+
+	// floorlog2(get_generalized_index(BeaconBlockBody, 'blob_kzg_commitments')) + 1 + ceillog2(MAX_BLOB_COMMITMENTS_PER_BLOCK)
+	expectedDepth := uint64(4 + 1 + bits.Len64(maxCommitments-1))
+	if depth, ok := c.GetUint("KZG_COMMITMENT_INCLUSION_PROOF_DEPTH"); ok && depth != expectedDepth {
+		return fmt.Errorf("invalid KZG_COMMITMENT_INCLUSION_PROOF_DEPTH %v: must be %v for MAX_BLOB_COMMITMENTS_PER_BLOCK %v", depth, expectedDepth, maxCommitments)
+	}
+
+	for _, key := range []string{"MAX_BLOBS_PER_BLOCK", "MAX_BLOBS_PER_BLOCK_ELECTRA"} {
+		if maxBlobs, ok := c.GetUint(key); ok && maxBlobs > maxCommitments {
+			return fmt.Errorf("invalid %v %v: exceeds MAX_BLOB_COMMITMENTS_PER_BLOCK %v", key, maxBlobs, maxCommitments)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config/presets"
+)
+
+// specSource is the value source of configs fetched from the spec endpoint of a beacon node.
+const specSource = "spec"
+
+// specPath is the beacon API endpoint returning the config and preset values of a beacon node.
+const specPath = "/eth/v1/config/spec"
+
+// specHTTPClient fetches the spec endpoint, the timeout keeps an unresponsive beacon node from stalling the build.
+var specHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// LoadConfigFromURL loads the consensus config of a running beacon node from its /eth/v1/config/spec endpoint.
+// The string values of the spec are parsed with the same rules as config files, unknown keys (constants) are ignored.
+// PRESET_BASE is inferred from the preset values of the spec if the beacon node does not report it.
+// Preset values of the spec that match the preset are not kept as config values.
+func LoadConfigFromURL(ctx context.Context, beaconURL string, options *LoadOptions) (*Config, error) {
+	data, err := fetchSpec(ctx, beaconURL)
+	if err != nil {
+		return nil, err
+	}
+
+	config := newConfig()
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("parsing spec response: %w", err)
+	}
+
+	if len(response.Data) == 0 {
+		return nil, errors.New("parsing spec response: missing data")
+	}
+
+	// JSON is parsed via the YAML parser, so the values are parsed like the values of config files
+	if err := config.parseValues(response.Data, specSource, config.values); err != nil {
+		return nil, err
+	}
+
+	if _, ok := config.values["PRESET_BASE"]; !ok && options.PresetFile == "" {
+		presetName, err := inferPreset(config.values)
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.Infof("inferred PRESET_BASE %v from the spec values", presetName)
+
+		config.values["PRESET_BASE"] = presetName
+	}
+
+	if err := config.load(options); err != nil {
+		return nil, err
+	}
+
+	for key, value := range config.values {
+		if keyDef, known := LookupKey(key); known && keyDef.Preset && fmt.Sprint(config.preset[key]) == fmt.Sprint(value) {
+			delete(config.values, key)
+		}
+	}
+
+	return config, nil
+}
+
+func fetchSpec(ctx context.Context, beaconURL string) ([]byte, error) {
+	specURL := beaconURL
+	if !strings.HasSuffix(specURL, specPath) {
+		specURL = strings.TrimRight(specURL, "/") + specPath
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, specURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := specHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spec from beacon node: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get spec from beacon node: status %v", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec from beacon node: %w", err)
+	}
+
+	return data, nil
+}
+
+// inferPreset returns the embedded preset with the fewest values differing from the given values.
+func inferPreset(values map[string]interface{}) (string, error) {
+	bestPreset := ""
+	bestMatches, bestMismatches := 0, 0
+
+	for _, presetName := range EmbeddedPresets() {
+		presetData, err := presets.PresetsFS.ReadFile(presetName + ".yaml")
+		if err != nil {
+			return "", fmt.Errorf("preset '%v' not found: %w", presetName, err)
+		}
+
+		presetConfig := newConfig()
+		if err := presetConfig.parseValues(presetData, "preset", presetConfig.preset); err != nil {
+			return "", err
+		}
+
+		matches, mismatches := 0, 0
+
+		for key, presetValue := range presetConfig.preset {
+			value, ok := values[key]
+			if !ok {
+				continue
+			}
+
+			if fmt.Sprint(value) == fmt.Sprint(presetValue) {
+				matches++
+			} else {
+				mismatches++
+			}
+		}
+
+		if matches > 0 && (bestPreset == "" || mismatches < bestMismatches || (mismatches == bestMismatches && matches > bestMatches)) {
+			bestPreset = presetName
+			bestMatches = matches
+			bestMismatches = mismatches
+		}
+	}
+
+	if bestPreset == "" {
+		return "", errors.New("PRESET_BASE not set and no preset values to infer it from")
+	}
+
+	return bestPreset, nil
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// newSpecServer serves the given values as /eth/v1/config/spec response, with all values as strings like beacon nodes.
+func newSpecServer(t *testing.T, preset string, values map[string]interface{}) *httptest.Server {
+	t.Helper()
+
+	presetData, err := os.ReadFile("presets/" + preset + ".yaml")
+	if err != nil {
+		t.Fatalf("failed to read preset: %v", err)
+	}
+
+	spec := map[string]interface{}{}
+	if err := yaml.Unmarshal(presetData, &spec); err != nil {
+		t.Fatalf("failed to parse preset: %v", err)
+	}
+
+	for key, value := range spec {
+		spec[key] = yamlString(value)
+	}
+
+	for key, value := range values {
+		spec[key] = value
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/config/spec" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"data": spec}); err != nil {
+			t.Errorf("failed to write response: %v", err)
+		}
+	}))
+}
+
+func yamlString(value interface{}) string {
+	data, _ := yaml.Marshal(value)
+	return strings.TrimSpace(string(data))
+}
+
+func TestLoadConfigFromURL(t *testing.T) {
+	specValues := map[string]interface{}{
+		"CONFIG_NAME":               "testnet",
+		"MIN_GENESIS_TIME":          "1606824000",
+		"GENESIS_FORK_VERSION":      "0x10000038",
+		"GENESIS_DELAY":             "60",
+		"ALTAIR_FORK_VERSION":       "0x20000038",
+		"ALTAIR_FORK_EPOCH":         "0",
+		"TERMINAL_TOTAL_DIFFICULTY": "58750000000000000000000",
+		"DEPOSIT_CONTRACT_ADDRESS":  "0x00000000219ab540356cbb839cbe05303d7705fa",
+		"DOMAIN_BEACON_PROPOSER":    "0x00000000",
+		"BLS_WITHDRAWAL_PREFIX":     "0x00",
+		"SLOTS_PER_EPOCH":           "16",
+		"BLOB_SCHEDULE": []map[string]string{
+			{"EPOCH": "20", "MAX_BLOBS_PER_BLOCK": "15"},
+		},
+	}
+
+	server := newSpecServer(t, "mainnet", specValues)
+	defer server.Close()
+
+	config, err := LoadConfigFromURL(context.Background(), server.URL+"/", &LoadOptions{
+		Overrides: []string{"GENESIS_DELAY=120"},
+	})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if preset, _ := config.GetString("PRESET_BASE"); preset != "mainnet" {
+		t.Fatalf("expected inferred PRESET_BASE mainnet, got %v", preset)
+	}
+
+	if version := config.GetBytesDefault("ALTAIR_FORK_VERSION", nil); len(version) != 4 || version[0] != 0x20 {
+		t.Fatalf("unexpected ALTAIR_FORK_VERSION: %x", version)
+	}
+
+	if ttd, _ := config.GetString("TERMINAL_TOTAL_DIFFICULTY"); ttd != "58750000000000000000000" {
+		t.Fatalf("unexpected TERMINAL_TOTAL_DIFFICULTY: %v", ttd)
+	}
+
+	if delay := config.GetUintDefault("GENESIS_DELAY", 0); delay != 120 {
+		t.Fatalf("expected overridden GENESIS_DELAY 120, got %v", delay)
+	}
+
+	if schedule := config.GetBlobSchedule(); len(schedule) != 1 || schedule[0].MaxBlobsPerBlock != 15 {
+		t.Fatalf("unexpected blob schedule: %v", schedule)
+	}
+
+	// constants are ignored, preset values matching the preset are not kept as config values
+	sources := map[string]string{}
+	for _, value := range config.EffectiveValues() {
+		sources[value.Key] = value.Source
+	}
+
+	if _, ok := sources["DOMAIN_BEACON_PROPOSER"]; ok {
+		t.Fatalf("expected constants to be ignored")
+	}
+
+	if sources["MAX_COMMITTEES_PER_SLOT"] != SourcePreset || sources["SLOTS_PER_EPOCH"] != SourceConfig {
+		t.Fatalf("unexpected sources: %v, %v", sources["MAX_COMMITTEES_PER_SLOT"], sources["SLOTS_PER_EPOCH"])
+	}
+
+	if len(config.Warnings()) != 0 {
+		t.Fatalf("unexpected warnings: %v", config.Warnings())
+	}
+}
+
+func TestLoadConfigFromURLPresets(t *testing.T) {
+	baseValues := map[string]interface{}{
+		"GENESIS_FORK_VERSION": "0x10000038",
+		"MIN_GENESIS_TIME":     "0",
+		"GENESIS_DELAY":        "60",
+	}
+
+	for _, preset := range []string{"minimal", "gnosis"} {
+		server := newSpecServer(t, preset, baseValues)
+
+		config, err := LoadConfigFromURL(context.Background(), server.URL+"/eth/v1/config/spec", &LoadOptions{})
+		if err != nil {
+			t.Fatalf("%v: failed to load config: %v", preset, err)
+		}
+
+		if presetBase, _ := config.GetString("PRESET_BASE"); presetBase != preset {
+			t.Fatalf("expected inferred PRESET_BASE %v, got %v", preset, presetBase)
+		}
+
+		server.Close()
+	}
+
+	server := newSpecServer(t, "mainnet", map[string]interface{}{"GENESIS_DELAY": "abc"})
+	defer server.Close()
+
+	_, err := LoadConfigFromURL(context.Background(), server.URL, &LoadOptions{})
+	if err == nil || !strings.Contains(err.Error(), "invalid spec value GENESIS_DELAY") {
+		t.Fatalf("expected invalid value error, got: %v", err)
+	}
+
+	_, err = LoadConfigFromURL(context.Background(), server.URL+"/missing", &LoadOptions{})
+	if err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Fatalf("expected status error, got: %v", err)
+	}
+}
@@ -0,0 +1,330 @@
+package config
+
+// ValueType is the type of a config or preset value.
+type ValueType int
+
+const (
+	// TypeUint is an unsigned 64 bit integer.
+	TypeUint ValueType = iota
+	// TypeBigUint is an unsigned integer of arbitrary size, stored as decimal string.
+	TypeBigUint
+	// TypeBytes is a hex encoded byte string (0x prefixed).
+	TypeBytes
+	// TypeString is a plain string.
+	TypeString
+	// TypeList is a YAML list.
+	TypeList
+	// TypeMap is a YAML mapping.
+	TypeMap
+)
+
+func (t ValueType) String() string {
+	switch t {
+	case TypeUint:
+		return "uint64"
+	case TypeBigUint:
+		return "big uint"
+	case TypeBytes:
+		return "bytes"
+	case TypeString:
+		return "string"
+	case TypeList:
+		return "list"
+	case TypeMap:
+		return "map"
+	default:
+		return "unknown"
+	}
+}
+
+// fork names of the schema keys
+const (
+	ForkPhase0    = "phase0"
+	ForkAltair    = "altair"
+	ForkBellatrix = "bellatrix"
+	ForkCapella   = "capella"
+	ForkDeneb     = "deneb"
+	ForkElectra   = "electra"
+	ForkFulu      = "fulu"
+	ForkGloas     = "gloas"
+)
+
+// Forks are the known forks in activation order.
+var Forks = []string{ForkPhase0, ForkAltair, ForkBellatrix, ForkCapella, ForkDeneb, ForkElectra, ForkFulu, ForkGloas}
+
+// KeyDef describes a known config or preset key.
+type KeyDef struct {
+	Name string
+	Type ValueType
+	// Fork is the fork that introduced the key.
+	Fork string
+	// Preset is set for preset keys, which are usually loaded from the preset referenced by PRESET_BASE.
+	Preset bool
+	// Required keys must be set if their fork is scheduled (always for phase0 keys).
+	Required bool
+	// Length is the byte length of TypeBytes values (0 for any length).
+	Length int
+	// Min and Max limit TypeUint values (Max 0 for no limit).
+	Min uint64
+	Max uint64
+	// Fields are the known fields of TypeMap values and of the objects in TypeList values (any value if empty).
+	Fields []*KeyDef
+	// Default is the value the builders fall back to if the key is not set (nil if there is no default),
+	// read via GetUintOrDefault and GetBytesOrDefault. Defaults of preset keys are the mainnet preset values.
+	Default interface{}
+}
+
+// configKeys are the known keys of the network config (config.yaml).
+var configKeys = []*KeyDef{
+	// phase0
+	{Name: "PRESET_BASE", Type: TypeString, Fork: ForkPhase0, Required: true},
+	{Name: "CONFIG_NAME", Type: TypeString, Fork: ForkPhase0},
+	{Name: "MIN_GENESIS_ACTIVE_VALIDATOR_COUNT", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "MIN_GENESIS_TIME", Type: TypeUint, Fork: ForkPhase0, Required: true, Default: uint64(0)},
+	{Name: "GENESIS_FORK_VERSION", Type: TypeBytes, Fork: ForkPhase0, Required: true, Length: 4, Default: []byte{0x00, 0x00, 0x00, 0x00}},
+	{Name: "GENESIS_DELAY", Type: TypeUint, Fork: ForkPhase0, Required: true, Default: uint64(604800)},
+	{Name: "SECONDS_PER_SLOT", Type: TypeUint, Fork: ForkPhase0, Min: 1, Default: uint64(12)},
+	{Name: "SECONDS_PER_ETH1_BLOCK", Type: TypeUint, Fork: ForkPhase0, Min: 1},
+	{Name: "MIN_VALIDATOR_WITHDRAWABILITY_DELAY", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "SHARD_COMMITTEE_PERIOD", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "ETH1_FOLLOW_DISTANCE", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "EJECTION_BALANCE", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "MIN_PER_EPOCH_CHURN_LIMIT", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "CHURN_LIMIT_QUOTIENT", Type: TypeUint, Fork: ForkPhase0, Min: 1},
+	{Name: "PROPOSER_SCORE_BOOST", Type: TypeUint, Fork: ForkPhase0, Max: 100},
+	{Name: "REORG_HEAD_WEIGHT_THRESHOLD", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "REORG_PARENT_WEIGHT_THRESHOLD", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "REORG_MAX_EPOCHS_SINCE_FINALIZATION", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "DEPOSIT_CHAIN_ID", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "DEPOSIT_NETWORK_ID", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "DEPOSIT_CONTRACT_ADDRESS", Type: TypeBytes, Fork: ForkPhase0, Length: 20},
+	{Name: "GOSSIP_MAX_SIZE", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "MAX_PAYLOAD_SIZE", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "MAX_CHUNK_SIZE", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "MAX_REQUEST_BLOCKS", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "EPOCHS_PER_SUBNET_SUBSCRIPTION", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "MIN_EPOCHS_FOR_BLOCK_REQUESTS", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "TTFB_TIMEOUT", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "RESP_TIMEOUT", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "ATTESTATION_PROPAGATION_SLOT_RANGE", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "MAXIMUM_GOSSIP_CLOCK_DISPARITY", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "MESSAGE_DOMAIN_INVALID_SNAPPY", Type: TypeBytes, Fork: ForkPhase0, Length: 4},
+	{Name: "MESSAGE_DOMAIN_VALID_SNAPPY", Type: TypeBytes, Fork: ForkPhase0, Length: 4},
+	{Name: "SUBNETS_PER_NODE", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "ATTESTATION_SUBNET_COUNT", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "ATTESTATION_SUBNET_EXTRA_BITS", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "ATTESTATION_SUBNET_PREFIX_BITS", Type: TypeUint, Fork: ForkPhase0},
+	{Name: "ATTESTATION_SUBNET_SHUFFLING_PREFIX_BITS", Type: TypeUint, Fork: ForkPhase0},
+
+	// altair
+	{Name: "ALTAIR_FORK_VERSION", Type: TypeBytes, Fork: ForkAltair, Required: true, Length: 4},
+	{Name: "ALTAIR_FORK_EPOCH", Type: TypeUint, Fork: ForkAltair, Default: uint64(farFutureEpoch)},
+	{Name: "INACTIVITY_SCORE_BIAS", Type: TypeUint, Fork: ForkAltair},
+	{Name: "INACTIVITY_SCORE_RECOVERY_RATE", Type: TypeUint, Fork: ForkAltair},
+	{Name: "MAX_REQUEST_LIGHT_CLIENT_UPDATES", Type: TypeUint, Fork: ForkAltair},
+
+	// bellatrix
+	{Name: "BELLATRIX_FORK_VERSION", Type: TypeBytes, Fork: ForkBellatrix, Required: true, Length: 4},
+	{Name: "BELLATRIX_FORK_EPOCH", Type: TypeUint, Fork: ForkBellatrix, Default: uint64(farFutureEpoch)},
+	{Name: "TERMINAL_TOTAL_DIFFICULTY", Type: TypeBigUint, Fork: ForkBellatrix},
+	{Name: "TERMINAL_BLOCK_HASH", Type: TypeBytes, Fork: ForkBellatrix, Length: 32},
+	{Name: "TERMINAL_BLOCK_HASH_ACTIVATION_EPOCH", Type: TypeUint, Fork: ForkBellatrix},
+
+	// capella
+	{Name: "CAPELLA_FORK_VERSION", Type: TypeBytes, Fork: ForkCapella, Required: true, Length: 4},
+	{Name: "CAPELLA_FORK_EPOCH", Type: TypeUint, Fork: ForkCapella, Default: uint64(farFutureEpoch)},
+
+	// deneb
+	{Name: "DENEB_FORK_VERSION", Type: TypeBytes, Fork: ForkDeneb, Required: true, Length: 4},
+	{Name: "DENEB_FORK_EPOCH", Type: TypeUint, Fork: ForkDeneb, Default: uint64(farFutureEpoch)},
+	{Name: "MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT", Type: TypeUint, Fork: ForkDeneb},
+	{Name: "MAX_REQUEST_BLOCKS_DENEB", Type: TypeUint, Fork: ForkDeneb},
+	{Name: "MAX_REQUEST_BLOB_SIDECARS", Type: TypeUint, Fork: ForkDeneb},
+	{Name: "MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS", Type: TypeUint, Fork: ForkDeneb},
+	{Name: "BLOB_SIDECAR_SUBNET_COUNT", Type: TypeUint, Fork: ForkDeneb},
+	{Name: "MAX_BLOBS_PER_BLOCK", Type: TypeUint, Fork: ForkDeneb},
+
+	// electra
+	{Name: "ELECTRA_FORK_VERSION", Type: TypeBytes, Fork: ForkElectra, Required: true, Length: 4},
+	{Name: "ELECTRA_FORK_EPOCH", Type: TypeUint, Fork: ForkElectra, Default: uint64(farFutureEpoch)},
+	{Name: "MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA", Type: TypeUint, Fork: ForkElectra},
+	{Name: "MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT", Type: TypeUint, Fork: ForkElectra},
+	{Name: "BLOB_SIDECAR_SUBNET_COUNT_ELECTRA", Type: TypeUint, Fork: ForkElectra},
+	{Name: "MAX_BLOBS_PER_BLOCK_ELECTRA", Type: TypeUint, Fork: ForkElectra, Default: uint64(9)},
+	{Name: "MAX_REQUEST_BLOB_SIDECARS_ELECTRA", Type: TypeUint, Fork: ForkElectra},
+
+	// fulu
+	{Name: "FULU_FORK_VERSION", Type: TypeBytes, Fork: ForkFulu, Required: true, Length: 4},
+	{Name: "FULU_FORK_EPOCH", Type: TypeUint, Fork: ForkFulu, Default: uint64(farFutureEpoch)},
+	{Name: "NUMBER_OF_CUSTODY_GROUPS", Type: TypeUint, Fork: ForkFulu},
+	{Name: "DATA_COLUMN_SIDECAR_SUBNET_COUNT", Type: TypeUint, Fork: ForkFulu},
+	{Name: "MAX_REQUEST_DATA_COLUMN_SIDECARS", Type: TypeUint, Fork: ForkFulu},
+	{Name: "SAMPLES_PER_SLOT", Type: TypeUint, Fork: ForkFulu},
+	{Name: "CUSTODY_REQUIREMENT", Type: TypeUint, Fork: ForkFulu},
+	{Name: "VALIDATOR_CUSTODY_REQUIREMENT", Type: TypeUint, Fork: ForkFulu},
+	{Name: "BALANCE_PER_ADDITIONAL_CUSTODY_GROUP", Type: TypeUint, Fork: ForkFulu},
+	{Name: "MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS", Type: TypeUint, Fork: ForkFulu},
+	{Name: "BLOB_SCHEDULE", Type: TypeList, Fork: ForkFulu, Fields: []*KeyDef{
+		{Name: "EPOCH", Type: TypeUint, Required: true},
+		{Name: "MAX_BLOBS_PER_BLOCK", Type: TypeUint, Required: true},
+	}},
+
+	// gloas
+	{Name: "GLOAS_FORK_VERSION", Type: TypeBytes, Fork: ForkGloas, Required: true, Length: 4},
+	{Name: "GLOAS_FORK_EPOCH", Type: TypeUint, Fork: ForkGloas, Default: uint64(farFutureEpoch)},
+}
+
+// presetKeys are the known keys of the presets (mainnet.yaml, minimal.yaml, gnosis.yaml).
+var presetKeys = []*KeyDef{
+	// phase0
+	{Name: "MAX_COMMITTEES_PER_SLOT", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1},
+	{Name: "TARGET_COMMITTEE_SIZE", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1, Default: uint64(128)},
+	{Name: "MAX_VALIDATORS_PER_COMMITTEE", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1},
+	{Name: "SHUFFLE_ROUND_COUNT", Type: TypeUint, Fork: ForkPhase0, Required: true, Max: 255, Default: uint64(90)},
+	{Name: "HYSTERESIS_QUOTIENT", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1},
+	{Name: "HYSTERESIS_DOWNWARD_MULTIPLIER", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "HYSTERESIS_UPWARD_MULTIPLIER", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "MIN_DEPOSIT_AMOUNT", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "MAX_EFFECTIVE_BALANCE", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1, Default: uint64(32_000_000_000)},
+	{Name: "EFFECTIVE_BALANCE_INCREMENT", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1, Default: uint64(1_000_000_000)},
+	{Name: "MIN_ATTESTATION_INCLUSION_DELAY", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "SLOTS_PER_EPOCH", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1, Default: uint64(32)},
+	{Name: "MIN_SEED_LOOKAHEAD", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "MAX_SEED_LOOKAHEAD", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "EPOCHS_PER_ETH1_VOTING_PERIOD", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1},
+	{Name: "SLOTS_PER_HISTORICAL_ROOT", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1, Default: uint64(8192)},
+	{Name: "MIN_EPOCHS_TO_INACTIVITY_PENALTY", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "EPOCHS_PER_HISTORICAL_VECTOR", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1, Default: uint64(65536)},
+	{Name: "EPOCHS_PER_SLASHINGS_VECTOR", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1, Default: uint64(8192)},
+	{Name: "HISTORICAL_ROOTS_LIMIT", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1},
+	{Name: "VALIDATOR_REGISTRY_LIMIT", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1, Default: uint64(1_099_511_627_776)},
+	{Name: "BASE_REWARD_FACTOR", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "WHISTLEBLOWER_REWARD_QUOTIENT", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1},
+	{Name: "PROPOSER_REWARD_QUOTIENT", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1},
+	{Name: "INACTIVITY_PENALTY_QUOTIENT", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1},
+	{Name: "MIN_SLASHING_PENALTY_QUOTIENT", Type: TypeUint, Fork: ForkPhase0, Required: true, Min: 1},
+	{Name: "PROPORTIONAL_SLASHING_MULTIPLIER", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "MAX_PROPOSER_SLASHINGS", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "MAX_ATTESTER_SLASHINGS", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "MAX_ATTESTATIONS", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "MAX_DEPOSITS", Type: TypeUint, Fork: ForkPhase0, Required: true},
+	{Name: "MAX_VOLUNTARY_EXITS", Type: TypeUint, Fork: ForkPhase0, Required: true},
+
+	// altair
+	{Name: "INACTIVITY_PENALTY_QUOTIENT_ALTAIR", Type: TypeUint, Fork: ForkAltair, Required: true, Min: 1},
+	{Name: "MIN_SLASHING_PENALTY_QUOTIENT_ALTAIR", Type: TypeUint, Fork: ForkAltair, Required: true, Min: 1},
+	{Name: "PROPORTIONAL_SLASHING_MULTIPLIER_ALTAIR", Type: TypeUint, Fork: ForkAltair, Required: true},
+	{Name: "SYNC_COMMITTEE_SIZE", Type: TypeUint, Fork: ForkAltair, Required: true, Min: 1, Default: uint64(512)},
+	{Name: "EPOCHS_PER_SYNC_COMMITTEE_PERIOD", Type: TypeUint, Fork: ForkAltair, Required: true, Min: 1},
+	{Name: "MIN_SYNC_COMMITTEE_PARTICIPANTS", Type: TypeUint, Fork: ForkAltair, Required: true},
+	{Name: "UPDATE_TIMEOUT", Type: TypeUint, Fork: ForkAltair, Required: true},
+
+	// bellatrix
+	{Name: "INACTIVITY_PENALTY_QUOTIENT_BELLATRIX", Type: TypeUint, Fork: ForkBellatrix, Required: true, Min: 1},
+	{Name: "MIN_SLASHING_PENALTY_QUOTIENT_BELLATRIX", Type: TypeUint, Fork: ForkBellatrix, Required: true, Min: 1},
+	{Name: "PROPORTIONAL_SLASHING_MULTIPLIER_BELLATRIX", Type: TypeUint, Fork: ForkBellatrix, Required: true},
+	{Name: "MAX_BYTES_PER_TRANSACTION", Type: TypeUint, Fork: ForkBellatrix, Required: true, Min: 1, Default: uint64(1_073_741_824)},
+	{Name: "MAX_TRANSACTIONS_PER_PAYLOAD", Type: TypeUint, Fork: ForkBellatrix, Required: true, Min: 1, Default: uint64(1_048_576)},
+	{Name: "BYTES_PER_LOGS_BLOOM", Type: TypeUint, Fork: ForkBellatrix, Required: true, Min: 1},
+	{Name: "MAX_EXTRA_DATA_BYTES", Type: TypeUint, Fork: ForkBellatrix, Required: true},
+
+	// capella
+	{Name: "MAX_BLS_TO_EXECUTION_CHANGES", Type: TypeUint, Fork: ForkCapella, Required: true},
+	{Name: "MAX_WITHDRAWALS_PER_PAYLOAD", Type: TypeUint, Fork: ForkCapella, Required: true, Min: 1, Default: uint64(16)},
+	{Name: "MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP", Type: TypeUint, Fork: ForkCapella, Required: true},
+
+	// deneb
+	{Name: "FIELD_ELEMENTS_PER_BLOB", Type: TypeUint, Fork: ForkDeneb, Required: true, Min: 1},
+	{Name: "MAX_BLOB_COMMITMENTS_PER_BLOCK", Type: TypeUint, Fork: ForkDeneb, Required: true, Min: 1, Default: uint64(4096)},
+	{Name: "KZG_COMMITMENT_INCLUSION_PROOF_DEPTH", Type: TypeUint, Fork: ForkDeneb, Required: true},
+
+	// electra
+	{Name: "MIN_ACTIVATION_BALANCE", Type: TypeUint, Fork: ForkElectra, Required: true},
+	{Name: "MAX_EFFECTIVE_BALANCE_ELECTRA", Type: TypeUint, Fork: ForkElectra, Required: true, Min: 1, Default: uint64(2_048_000_000_000)},
+	{Name: "PENDING_DEPOSITS_LIMIT", Type: TypeUint, Fork: ForkElectra, Required: true, Min: 1},
+	{Name: "PENDING_PARTIAL_WITHDRAWALS_LIMIT", Type: TypeUint, Fork: ForkElectra, Required: true, Min: 1},
+	{Name: "PENDING_CONSOLIDATIONS_LIMIT", Type: TypeUint, Fork: ForkElectra, Required: true, Min: 1},
+	{Name: "MIN_SLASHING_PENALTY_QUOTIENT_ELECTRA", Type: TypeUint, Fork: ForkElectra, Required: true, Min: 1},
+	{Name: "WHISTLEBLOWER_REWARD_QUOTIENT_ELECTRA", Type: TypeUint, Fork: ForkElectra, Required: true, Min: 1},
+	{Name: "MAX_ATTESTER_SLASHINGS_ELECTRA", Type: TypeUint, Fork: ForkElectra, Required: true},
+	{Name: "MAX_ATTESTATIONS_ELECTRA", Type: TypeUint, Fork: ForkElectra, Required: true},
+	{Name: "MAX_CONSOLIDATION_REQUESTS_PER_PAYLOAD", Type: TypeUint, Fork: ForkElectra, Required: true},
+	{Name: "MAX_DEPOSIT_REQUESTS_PER_PAYLOAD", Type: TypeUint, Fork: ForkElectra, Required: true},
+	{Name: "MAX_WITHDRAWAL_REQUESTS_PER_PAYLOAD", Type: TypeUint, Fork: ForkElectra, Required: true},
+	{Name: "MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP", Type: TypeUint, Fork: ForkElectra, Required: true},
+	{Name: "MAX_PENDING_DEPOSITS_PER_EPOCH", Type: TypeUint, Fork: ForkElectra, Required: true},
+
+	// fulu
+	{Name: "FIELD_ELEMENTS_PER_CELL", Type: TypeUint, Fork: ForkFulu, Min: 1},
+	{Name: "FIELD_ELEMENTS_PER_EXT_BLOB", Type: TypeUint, Fork: ForkFulu, Min: 1},
+	{Name: "KZG_COMMITMENTS_INCLUSION_PROOF_DEPTH", Type: TypeUint, Fork: ForkFulu},
+}
+
+var schema = buildSchema()
+
+func buildSchema() map[string]*KeyDef {
+	keys := make(map[string]*KeyDef, len(configKeys)+len(presetKeys))
+
+	for _, key := range configKeys {
+		keys[key.Name] = key
+	}
+
+	for _, key := range presetKeys {
+		key.Preset = true
+		keys[key.Name] = key
+	}
+
+	return keys
+}
+
+// LookupKey returns the schema definition of a known config or preset key.
+func LookupKey(name string) (*KeyDef, bool) {
+	key, ok := schema[name]
+	return key, ok
+}
+
+// suggestKey returns the known key closest to a misspelt key, or an empty string if there is no close match.
+func suggestKey(name string) string {
+	suggestion := ""
+	bestDistance := 3 // only suggest keys within an edit distance of 2
+
+	for known := range schema {
+		if distance := editDistance(name, known); distance < bestDistance || (distance == bestDistance && known < suggestion) {
+			suggestion = known
+			bestDistance = distance
+		}
+	}
+
+	if bestDistance > 2 {
+		return ""
+	}
+
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance of two strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BlobParameters are the blob limits of a blob schedule entry.
+type BlobParameters struct {
+	Epoch            uint64
+	MaxBlobsPerBlock uint64
+}
+
+// parseListValue parses a list value. Items are parsed as objects with the fields of the key definition,
+// or by their format if the key definition has no fields.
+func parseListValue(keyDef *KeyDef, node *yaml.Node) (interface{}, error) {
+	if node.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("expected a %v", keyDef.Type)
+	}
+
+	values := make([]interface{}, 0, len(node.Content))
+
+	for i, itemNode := range node.Content {
+		if len(keyDef.Fields) == 0 {
+			values = append(values, parseUnknownValue(itemNode))
+			continue
+		}
+
+		value, err := parseMapValue(&KeyDef{Name: keyDef.Name, Type: TypeMap, Fields: keyDef.Fields}, itemNode)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// parseMapValue parses a mapping value with the fields of the key definition,
+// or by the format of the values if the key definition has no fields.
+func parseMapValue(keyDef *KeyDef, node *yaml.Node) (interface{}, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a %v", keyDef.Type)
+	}
+
+	if len(keyDef.Fields) == 0 {
+		return parseUnknownValue(node), nil
+	}
+
+	fieldDefs := make(map[string]*KeyDef, len(keyDef.Fields))
+	for _, fieldDef := range keyDef.Fields {
+		fieldDefs[fieldDef.Name] = fieldDef
+	}
+
+	values := make(map[string]interface{}, len(keyDef.Fields))
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		fieldDef, known := fieldDefs[keyNode.Value]
+		if !known {
+			return nil, fmt.Errorf("unknown field %v on line %v", keyNode.Value, keyNode.Line)
+		}
+
+		if _, exists := values[fieldDef.Name]; exists {
+			return nil, fmt.Errorf("duplicate field %v on line %v", fieldDef.Name, keyNode.Line)
+		}
+
+		value, err := parseValue(fieldDef, valueNode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v on line %v: %w", fieldDef.Name, keyNode.Line, err)
+		}
+
+		values[fieldDef.Name] = value
+	}
+
+	for _, fieldDef := range keyDef.Fields {
+		if _, exists := values[fieldDef.Name]; fieldDef.Required && !exists {
+			return nil, fmt.Errorf("missing %v on line %v", fieldDef.Name, node.Line)
+		}
+	}
+
+	return values, nil
+}
+
+func (c *Config) GetList(key string) ([]interface{}, bool) {
+	value, ok := c.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	if list, ok := value.([]interface{}); ok {
+		return list, true
+	}
+
+	return nil, false
+}
+
+func (c *Config) GetMap(key string) (map[string]interface{}, bool) {
+	value, ok := c.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	if values, ok := value.(map[string]interface{}); ok {
+		return values, true
+	}
+
+	return nil, false
+}
+
+// GetBlobSchedule returns the entries of the BLOB_SCHEDULE sorted by epoch.
+func (c *Config) GetBlobSchedule() []*BlobParameters {
+	list, _ := c.GetList("BLOB_SCHEDULE")
+	schedule := make([]*BlobParameters, 0, len(list))
+
+	for _, item := range list {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		epoch, _ := entry["EPOCH"].(uint64)
+		maxBlobs, _ := entry["MAX_BLOBS_PER_BLOCK"].(uint64)
+
+		schedule = append(schedule, &BlobParameters{
+			Epoch:            epoch,
+			MaxBlobsPerBlock: maxBlobs,
+		})
+	}
+
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].Epoch < schedule[j].Epoch
+	})
+
+	return schedule
+}
+
+// GetBlobParameters returns the blob parameters in effect at the given epoch (get_blob_parameters in the fulu spec).
+// Before the first blob schedule entry, the electra blob limit applies.
+func (c *Config) GetBlobParameters(epoch uint64) *BlobParameters {
+	schedule := c.GetBlobSchedule()
+
+	for i := len(schedule) - 1; i >= 0; i-- {
+		if epoch >= schedule[i].Epoch {
+			return schedule[i]
+		}
+	}
+
+	return &BlobParameters{
+		Epoch:            c.GetUintOrDefault("ELECTRA_FORK_EPOCH"),
+		MaxBlobsPerBlock: c.GetUintOrDefault("MAX_BLOBS_PER_BLOCK_ELECTRA"),
+	}
+}
+
+// checkBlobSchedule checks that the blob schedule epochs are unique and the blob limits fit into the SSZ types.
+func (c *Config) checkBlobSchedule() error {
+	schedule := c.GetBlobSchedule()
+	maxCommitments := c.GetUintOrDefault("MAX_BLOB_COMMITMENTS_PER_BLOCK")
+
+	for i, entry := range schedule {
+		if i > 0 && schedule[i-1].Epoch == entry.Epoch {
+			return fmt.Errorf("invalid BLOB_SCHEDULE: duplicate epoch %v", entry.Epoch)
+		}
+
+		if entry.MaxBlobsPerBlock > maxCommitments {
+			return fmt.Errorf("invalid BLOB_SCHEDULE: MAX_BLOBS_PER_BLOCK %v at epoch %v exceeds MAX_BLOB_COMMITMENTS_PER_BLOCK %v", entry.MaxBlobsPerBlock, entry.Epoch, maxCommitments)
+		}
+	}
+
+	return nil
+}
@@ -23,3 +23,33 @@ func LoadEth1GenesisConfig(configPath string) (*core.Genesis, error) {
 
 	return &eth1Genesis, nil
 }
+
+// SetGenesisTimestamp sets the timestamp of the execution genesis and moves the time based forks along,
+// so the forks active at genesis stay active and later forks keep their offset to the genesis timestamp.
+func SetGenesisTimestamp(genesis *core.Genesis, timestamp uint64) {
+	oldTimestamp := genesis.Timestamp
+	genesis.Timestamp = timestamp
+
+	if genesis.Config == nil {
+		return
+	}
+
+	forkTimes := []*uint64{
+		genesis.Config.ShanghaiTime,
+		genesis.Config.CancunTime,
+		genesis.Config.PragueTime,
+		genesis.Config.OsakaTime,
+		genesis.Config.VerkleTime,
+	}
+
+	for _, forkTime := range forkTimes {
+		switch {
+		case forkTime == nil:
+			continue
+		case *forkTime <= oldTimestamp:
+			*forkTime = min(*forkTime, timestamp)
+		default:
+			*forkTime = *forkTime - oldTimestamp + timestamp
+		}
+	}
+}
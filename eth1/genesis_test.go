@@ -0,0 +1,45 @@
+package eth1
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSetGenesisTimestamp(t *testing.T) {
+	u64 := func(value uint64) *uint64 {
+		return &value
+	}
+
+	genesis := &core.Genesis{
+		Timestamp: 1000,
+		Config: &params.ChainConfig{
+			ShanghaiTime: u64(0),
+			CancunTime:   u64(1000),
+			PragueTime:   u64(1600),
+		},
+	}
+
+	oldHash := genesis.ToBlock().Hash()
+
+	SetGenesisTimestamp(genesis, 5000)
+
+	if genesis.Timestamp != 5000 || *genesis.Config.ShanghaiTime != 0 || *genesis.Config.CancunTime != 1000 || *genesis.Config.PragueTime != 5600 {
+		t.Fatalf("unexpected fork times after moving genesis forward: %v, %v, %v", *genesis.Config.ShanghaiTime, *genesis.Config.CancunTime, *genesis.Config.PragueTime)
+	}
+
+	if genesis.Config.OsakaTime != nil {
+		t.Fatalf("unexpected osaka time %v", *genesis.Config.OsakaTime)
+	}
+
+	if genesis.ToBlock().Hash() == oldHash {
+		t.Fatalf("expected a new genesis block hash")
+	}
+
+	SetGenesisTimestamp(genesis, 500)
+
+	if genesis.Timestamp != 500 || *genesis.Config.ShanghaiTime != 0 || *genesis.Config.CancunTime != 500 || *genesis.Config.PragueTime != 1100 {
+		t.Fatalf("unexpected fork times after moving genesis back: %v, %v, %v", *genesis.Config.ShanghaiTime, *genesis.Config.CancunTime, *genesis.Config.PragueTime)
+	}
+}
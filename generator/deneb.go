@@ -25,6 +25,8 @@ type denebBuilder struct {
 	clConfig        *config.Config
 	dynSsz          *dynssz.DynSsz
 	shadowForkBlock *types.Block
+	interopGenesis  *InteropGenesis
+	genesisTime     uint64
 	validators      []*validators.Validator
 }
 
@@ -40,6 +42,14 @@ func (b *denebBuilder) SetShadowForkBlock(block *types.Block) {
 	b.shadowForkBlock = block
 }
 
+func (b *denebBuilder) SetInteropGenesis(interopGenesis *InteropGenesis) {
+	b.interopGenesis = interopGenesis
+}
+
+func (b *denebBuilder) SetGenesisTime(genesisTime uint64) {
+	b.genesisTime = genesisTime
+}
+
 func (b *denebBuilder) AddValidators(validators []*validators.Validator) {
 	b.validators = append(b.validators, validators...)
 }
@@ -102,12 +112,12 @@ func (b *denebBuilder) BuildState() (*spec.VersionedBeaconState, error) {
 		ExcessBlobGas:    *genesisBlock.ExcessBlobGas(),
 	}
 
-	depositRoot, err := utils.ComputeDepositRoot(b.clConfig)
+	eth1Genesis, err := getEth1Genesis(b.clConfig, genesisBlock, b.interopGenesis, b.genesisTime)
 	if err != nil {
-		return nil, fmt.Errorf("failed to compute deposit root: %w", err)
+		return nil, err
 	}
 
-	syncCommitteeSize := b.clConfig.GetUintDefault("SYNC_COMMITTEE_SIZE", 512)
+	syncCommitteeSize := b.clConfig.GetUintOrDefault("SYNC_COMMITTEE_SIZE")
 	syncCommitteeMaskBytes := syncCommitteeSize / 8
 
 	if syncCommitteeSize%8 != 0 {
@@ -133,38 +143,30 @@ func (b *denebBuilder) BuildState() (*spec.VersionedBeaconState, error) {
 
 	clValidators, validatorsRoot := utils.GetGenesisValidators(b.clConfig, b.validators)
 
-	syncCommittee, err := utils.GetGenesisSyncCommittee(b.clConfig, clValidators, phase0.Hash32(genesisBlockHash))
+	syncCommittee, err := utils.GetGenesisSyncCommittee(b.clConfig, clValidators, eth1Genesis.blockHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get genesis sync committee: %w", err)
 	}
 
-	genesisDelay := b.clConfig.GetUintDefault("GENESIS_DELAY", 604800)
-	blocksPerHistoricalRoot := b.clConfig.GetUintDefault("SLOTS_PER_HISTORICAL_ROOT", 8192)
-	epochsPerSlashingVector := b.clConfig.GetUintDefault("EPOCHS_PER_SLASHINGS_VECTOR", 8192)
-
-	minGenesisTime := b.clConfig.GetUintDefault("MIN_GENESIS_TIME", 0)
-	if minGenesisTime == 0 {
-		minGenesisTime = genesisBlock.Time()
-	}
+	blocksPerHistoricalRoot := b.clConfig.GetUintOrDefault("SLOTS_PER_HISTORICAL_ROOT")
+	epochsPerSlashingVector := b.clConfig.GetUintOrDefault("EPOCHS_PER_SLASHINGS_VECTOR")
 
 	genesisState := &deneb.BeaconState{
-		GenesisTime:           minGenesisTime + genesisDelay,
+		GenesisTime:           eth1Genesis.genesisTime,
 		GenesisValidatorsRoot: validatorsRoot,
 		Fork:                  GetStateForkConfig(spec.DataVersionDeneb, b.clConfig),
 		LatestBlockHeader: &phase0.BeaconBlockHeader{
 			BodyRoot: genesisBlockBodyRoot,
 		},
-		BlockRoots: make([]phase0.Root, blocksPerHistoricalRoot),
-		StateRoots: make([]phase0.Root, blocksPerHistoricalRoot),
-		ETH1Data: &phase0.ETH1Data{
-			DepositRoot: depositRoot,
-			BlockHash:   genesisBlockHash[:],
-		},
+		BlockRoots:                   make([]phase0.Root, blocksPerHistoricalRoot),
+		StateRoots:                   make([]phase0.Root, blocksPerHistoricalRoot),
+		ETH1Data:                     eth1Genesis.eth1Data,
+		ETH1DepositIndex:             eth1Genesis.depositIndex,
 		JustificationBits:            make([]byte, 1),
 		PreviousJustifiedCheckpoint:  &phase0.Checkpoint{},
 		CurrentJustifiedCheckpoint:   &phase0.Checkpoint{},
 		FinalizedCheckpoint:          &phase0.Checkpoint{},
-		RANDAOMixes:                  utils.SeedRandomMixes(phase0.Hash32(genesisBlockHash), b.clConfig),
+		RANDAOMixes:                  utils.SeedRandomMixes(eth1Genesis.blockHash, b.clConfig),
 		Validators:                   clValidators,
 		Balances:                     utils.GetGenesisBalances(b.clConfig, b.validators),
 		Slashings:                    make([]phase0.Gwei, epochsPerSlashingVector),
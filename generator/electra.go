@@ -26,6 +26,8 @@ type electraBuilder struct {
 	clConfig        *config.Config
 	dynSsz          *dynssz.DynSsz
 	shadowForkBlock *types.Block
+	interopGenesis  *InteropGenesis
+	genesisTime     uint64
 	validators      []*validators.Validator
 }
 
@@ -41,6 +43,14 @@ func (b *electraBuilder) SetShadowForkBlock(block *types.Block) {
 	b.shadowForkBlock = block
 }
 
+func (b *electraBuilder) SetInteropGenesis(interopGenesis *InteropGenesis) {
+	b.interopGenesis = interopGenesis
+}
+
+func (b *electraBuilder) SetGenesisTime(genesisTime uint64) {
+	b.genesisTime = genesisTime
+}
+
 func (b *electraBuilder) AddValidators(validators []*validators.Validator) {
 	b.validators = append(b.validators, validators...)
 }
@@ -103,12 +113,12 @@ func (b *electraBuilder) BuildState() (*spec.VersionedBeaconState, error) {
 		ExcessBlobGas:    *genesisBlock.ExcessBlobGas(),
 	}
 
-	depositRoot, err := utils.ComputeDepositRoot(b.clConfig)
+	eth1Genesis, err := getEth1Genesis(b.clConfig, genesisBlock, b.interopGenesis, b.genesisTime)
 	if err != nil {
-		return nil, fmt.Errorf("failed to compute deposit root: %w", err)
+		return nil, err
 	}
 
-	syncCommitteeSize := b.clConfig.GetUintDefault("SYNC_COMMITTEE_SIZE", 512)
+	syncCommitteeSize := b.clConfig.GetUintOrDefault("SYNC_COMMITTEE_SIZE")
 	syncCommitteeMaskBytes := syncCommitteeSize / 8
 
 	if syncCommitteeSize%8 != 0 {
@@ -135,38 +145,30 @@ func (b *electraBuilder) BuildState() (*spec.VersionedBeaconState, error) {
 
 	clValidators, validatorsRoot := utils.GetGenesisValidators(b.clConfig, b.validators)
 
-	syncCommittee, err := utils.GetGenesisSyncCommittee(b.clConfig, clValidators, phase0.Hash32(genesisBlockHash))
+	syncCommittee, err := utils.GetGenesisSyncCommittee(b.clConfig, clValidators, eth1Genesis.blockHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get genesis sync committee: %w", err)
 	}
 
-	genesisDelay := b.clConfig.GetUintDefault("GENESIS_DELAY", 604800)
-	blocksPerHistoricalRoot := b.clConfig.GetUintDefault("SLOTS_PER_HISTORICAL_ROOT", 8192)
-	epochsPerSlashingVector := b.clConfig.GetUintDefault("EPOCHS_PER_SLASHINGS_VECTOR", 8192)
-
-	minGenesisTime := b.clConfig.GetUintDefault("MIN_GENESIS_TIME", 0)
-	if minGenesisTime == 0 {
-		minGenesisTime = genesisBlock.Time()
-	}
+	blocksPerHistoricalRoot := b.clConfig.GetUintOrDefault("SLOTS_PER_HISTORICAL_ROOT")
+	epochsPerSlashingVector := b.clConfig.GetUintOrDefault("EPOCHS_PER_SLASHINGS_VECTOR")
 
 	genesisState := &electra.BeaconState{
-		GenesisTime:           minGenesisTime + genesisDelay,
+		GenesisTime:           eth1Genesis.genesisTime,
 		GenesisValidatorsRoot: validatorsRoot,
 		Fork:                  GetStateForkConfig(spec.DataVersionElectra, b.clConfig),
 		LatestBlockHeader: &phase0.BeaconBlockHeader{
 			BodyRoot: genesisBlockBodyRoot,
 		},
-		BlockRoots: make([]phase0.Root, blocksPerHistoricalRoot),
-		StateRoots: make([]phase0.Root, blocksPerHistoricalRoot),
-		ETH1Data: &phase0.ETH1Data{
-			DepositRoot: depositRoot,
-			BlockHash:   genesisBlockHash[:],
-		},
+		BlockRoots:                   make([]phase0.Root, blocksPerHistoricalRoot),
+		StateRoots:                   make([]phase0.Root, blocksPerHistoricalRoot),
+		ETH1Data:                     eth1Genesis.eth1Data,
+		ETH1DepositIndex:             eth1Genesis.depositIndex,
 		JustificationBits:            make([]byte, 1),
 		PreviousJustifiedCheckpoint:  &phase0.Checkpoint{},
 		CurrentJustifiedCheckpoint:   &phase0.Checkpoint{},
 		FinalizedCheckpoint:          &phase0.Checkpoint{},
-		RANDAOMixes:                  utils.SeedRandomMixes(phase0.Hash32(genesisBlockHash), b.clConfig),
+		RANDAOMixes:                  utils.SeedRandomMixes(eth1Genesis.blockHash, b.clConfig),
 		Validators:                   clValidators,
 		Balances:                     utils.GetGenesisBalances(b.clConfig, b.validators),
 		Slashings:                    make([]phase0.Gwei, epochsPerSlashingVector),
@@ -176,6 +178,7 @@ func (b *electraBuilder) BuildState() (*spec.VersionedBeaconState, error) {
 		CurrentSyncCommittee:         syncCommittee,
 		NextSyncCommittee:            syncCommittee,
 		LatestExecutionPayloadHeader: execHeader,
+		DepositRequestsStartIndex:    eth1Genesis.depositRequestsStartIndex,
 	}
 
 	versionedState := &spec.VersionedBeaconState{
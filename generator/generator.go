@@ -16,6 +16,9 @@ type NewGenesisBuilderFn func(elGenesis *core.Genesis, clConfig *config.Config)
 
 type GenesisBuilder interface {
 	SetShadowForkBlock(block *types.Block)
+	SetInteropGenesis(interopGenesis *InteropGenesis)
+	// SetGenesisTime sets the genesis time instead of MIN_GENESIS_TIME + GENESIS_DELAY (0 to unset).
+	SetGenesisTime(genesisTime uint64)
 	AddValidators(validators []*validators.Validator)
 	BuildState() (*spec.VersionedBeaconState, error)
 	Serialize(state *spec.VersionedBeaconState, contentType http.ContentType) ([]byte, error)
@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+	"github.com/ethpandaops/eth-beacon-genesis/utils"
+	"github.com/ethpandaops/eth-beacon-genesis/validators"
+)
+
+// genesisStateRoot returns the hash tree root and the execution genesis block hash (if any) of a genesis state.
+func genesisStateRoot(clConfig *config.Config, state *spec.VersionedBeaconState) (phase0.Root, common.Hash, error) {
+	var (
+		stateObj  interface{}
+		blockHash common.Hash
+	)
+
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		stateObj = state.Phase0
+	case spec.DataVersionAltair:
+		stateObj = state.Altair
+	case spec.DataVersionBellatrix:
+		stateObj = state.Bellatrix
+		blockHash = common.Hash(state.Bellatrix.LatestExecutionPayloadHeader.BlockHash)
+	case spec.DataVersionCapella:
+		stateObj = state.Capella
+		blockHash = common.Hash(state.Capella.LatestExecutionPayloadHeader.BlockHash)
+	case spec.DataVersionDeneb:
+		stateObj = state.Deneb
+		blockHash = common.Hash(state.Deneb.LatestExecutionPayloadHeader.BlockHash)
+	case spec.DataVersionElectra:
+		stateObj = state.Electra
+		blockHash = common.Hash(state.Electra.LatestExecutionPayloadHeader.BlockHash)
+	default:
+		return phase0.Root{}, common.Hash{}, fmt.Errorf("unsupported state version %v", state.Version)
+	}
+
+	root, err := utils.GetDynSSZ(clConfig).HashTreeRoot(stateObj)
+	if err != nil {
+		return phase0.Root{}, common.Hash{}, err
+	}
+
+	return root, blockHash, nil
+}
+
+// TestNetworkGenesisSnapshot builds genesis states from the embedded network configs and the execution genesis of the
+// networks with the deterministic interop validator keys, and compares them to state roots pinned from earlier builds
+// of this tool. It is a snapshot test that catches unintended changes of the builders, not a check against the real
+// genesis states of the networks: their validator sets (deposits of the mainnet deposit contract and unpublished
+// testnet mnemonics) are not reproducible. Only the execution genesis block hashes are checked against known values.
+func TestNetworkGenesisSnapshot(t *testing.T) {
+	tests := []struct {
+		network   string
+		elGenesis *core.Genesis
+		version   spec.DataVersion
+		// blockHash is the execution genesis hash in the state of post-merge genesis states
+		blockHash common.Hash
+		stateRoot string
+	}{
+		{"mainnet", core.DefaultGenesisBlock(), spec.DataVersionPhase0, common.Hash{},
+			"0x0f5dab916c84f4fca1f87d0bd2fff7ace48fab7848dff7b899ebc55bb8ea9877"},
+		{"sepolia", core.DefaultSepoliaGenesisBlock(), spec.DataVersionPhase0, common.Hash{},
+			"0x9b6f99a5d0030abe889ac9c1506c7db3a47398a44add8286ae061f3b66ab3646"},
+		{"holesky", core.DefaultHoleskyGenesisBlock(), spec.DataVersionBellatrix, params.HoleskyGenesisHash,
+			"0xfd6834d5d964a8994fdfbafae4d7202de5a6db01140be6564f6b0b1e980b765a"},
+		{"hoodi", core.DefaultHoodiGenesisBlock(), spec.DataVersionDeneb, params.HoodiGenesisHash,
+			"0xe69a85a94eb284a9a14347f38c82892257b7f28a088d6dbffca10198e65d24e1"},
+		{"minimal", core.DeveloperGenesisBlock(30_000_000, nil), spec.DataVersionPhase0, common.Hash{},
+			"0xeae27da418af8b1c2bfd8a94a4a96b37d657be6f5e40421e715f05d4bc92d7bc"},
+	}
+
+	clValidators, err := validators.GenerateInteropValidators(0, 64)
+	if err != nil {
+		t.Fatalf("failed to generate interop validators: %v", err)
+	}
+
+	for _, test := range tests {
+		clConfig, err := config.LoadNetworkConfig(test.network, &config.LoadOptions{Strict: true})
+		if err != nil {
+			t.Fatalf("%v: failed to load config: %v", test.network, err)
+		}
+
+		builder := NewGenesisBuilder(test.elGenesis, clConfig)
+		builder.AddValidators(clValidators)
+
+		state, err := builder.BuildState()
+		if err != nil {
+			t.Fatalf("%v: failed to build genesis state: %v", test.network, err)
+		}
+
+		if state.Version != test.version {
+			t.Fatalf("%v: unexpected genesis state version %v, expected %v", test.network, state.Version, test.version)
+		}
+
+		stateRoot, blockHash, err := genesisStateRoot(clConfig, state)
+		if err != nil {
+			t.Fatalf("%v: failed to compute genesis state root: %v", test.network, err)
+		}
+
+		if blockHash != test.blockHash {
+			t.Fatalf("%v: unexpected execution genesis block hash %v, expected %v", test.network, blockHash, test.blockHash)
+		}
+
+		if fmt.Sprintf("%#x", stateRoot) != test.stateRoot {
+			t.Fatalf("%v: unexpected genesis state root %#x, expected %v", test.network, stateRoot, test.stateRoot)
+		}
+	}
+}
+
+func TestGenesisTime(t *testing.T) {
+	clConfig, err := config.LoadNetworkConfig("minimal", &config.LoadOptions{})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	clValidators, err := validators.GenerateInteropValidators(0, 64)
+	if err != nil {
+		t.Fatalf("failed to generate interop validators: %v", err)
+	}
+
+	elGenesis := core.DeveloperGenesisBlock(30_000_000, nil)
+	elGenesis.Timestamp = 1700000000
+
+	if genesisTime := GetGenesisTime(clConfig, elGenesis.Timestamp); genesisTime != 1578009600+300 {
+		t.Fatalf("unexpected default genesis time %v", genesisTime)
+	}
+
+	for _, genesisTime := range []uint64{0, 1800000000} {
+		builder := NewGenesisBuilder(elGenesis, clConfig)
+		builder.AddValidators(clValidators)
+		builder.SetGenesisTime(genesisTime)
+
+		state, err := builder.BuildState()
+		if err != nil {
+			t.Fatalf("failed to build genesis state: %v", err)
+		}
+
+		expectedTime := genesisTime
+		if expectedTime == 0 {
+			expectedTime = 1578009600 + 300
+		}
+
+		if state.Phase0.GenesisTime != expectedTime {
+			t.Fatalf("unexpected genesis time %v, expected %v", state.Phase0.GenesisTime, expectedTime)
+		}
+	}
+
+	noMinGenesisConfig, err := config.LoadNetworkConfig("minimal", &config.LoadOptions{Overrides: []string{"MIN_GENESIS_TIME=0"}})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if genesisTime := GetGenesisTime(noMinGenesisConfig, elGenesis.Timestamp); genesisTime != 1700000000+300 {
+		t.Fatalf("unexpected default genesis time without MIN_GENESIS_TIME %v", genesisTime)
+	}
+}
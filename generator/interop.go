@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+	"github.com/ethpandaops/eth-beacon-genesis/utils"
+)
+
+// InteropEth1BlockHash is the mocked eth1 block hash used by the clients' interop genesis states.
+var InteropEth1BlockHash = phase0.Hash32{
+	0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42,
+	0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42,
+}
+
+// unsetDepositRequestsStartIndex is the initial deposit_requests_start_index of electra genesis states.
+const unsetDepositRequestsStartIndex = ^uint64(0)
+
+// InteropGenesis builds the genesis state with the interop conventions: the mocked eth1 block hash, a deposit root
+// covering the signed genesis deposits and an explicit genesis time. It does not reproduce the interop genesis states
+// generated by the clients byte-for-byte.
+type InteropGenesis struct {
+	// GenesisTime is used as is (GENESIS_DELAY is not applied).
+	GenesisTime uint64
+	// DepositRoot is the hash tree root of the genesis deposit data list.
+	DepositRoot phase0.Root
+	// DepositCount is the number of genesis deposits.
+	DepositCount uint64
+}
+
+// eth1Genesis holds the eth1 related fields of the genesis state.
+type eth1Genesis struct {
+	blockHash                 phase0.Hash32
+	eth1Data                  *phase0.ETH1Data
+	depositIndex              uint64
+	depositRequestsStartIndex uint64
+	genesisTime               uint64
+}
+
+// GetGenesisTime returns the default genesis time: MIN_GENESIS_TIME + GENESIS_DELAY,
+// or the execution genesis block time + GENESIS_DELAY if MIN_GENESIS_TIME is not set.
+func GetGenesisTime(clConfig *config.Config, genesisBlockTime uint64) uint64 {
+	genesisDelay := clConfig.GetUintOrDefault("GENESIS_DELAY")
+
+	minGenesisTime := clConfig.GetUintOrDefault("MIN_GENESIS_TIME")
+	if minGenesisTime == 0 {
+		minGenesisTime = genesisBlockTime
+	}
+
+	return minGenesisTime + genesisDelay
+}
+
+// getEth1Genesis returns the eth1 related fields of the genesis state.
+// The genesis time defaults to GetGenesisTime if not set explicitly (0).
+func getEth1Genesis(clConfig *config.Config, genesisBlock *types.Block, interopGenesis *InteropGenesis, genesisTime uint64) (*eth1Genesis, error) {
+	if interopGenesis != nil {
+		return &eth1Genesis{
+			blockHash: InteropEth1BlockHash,
+			eth1Data: &phase0.ETH1Data{
+				DepositRoot:  interopGenesis.DepositRoot,
+				DepositCount: interopGenesis.DepositCount,
+				BlockHash:    InteropEth1BlockHash[:],
+			},
+			depositIndex:              interopGenesis.DepositCount,
+			depositRequestsStartIndex: unsetDepositRequestsStartIndex,
+			genesisTime:               interopGenesis.GenesisTime,
+		}, nil
+	}
+
+	genesisBlockHash := genesisBlock.Hash()
+
+	depositRoot, err := utils.ComputeDepositRoot(clConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute deposit root: %w", err)
+	}
+
+	if genesisTime == 0 {
+		genesisTime = GetGenesisTime(clConfig, genesisBlock.Time())
+	}
+
+	return &eth1Genesis{
+		blockHash: phase0.Hash32(genesisBlockHash),
+		eth1Data: &phase0.ETH1Data{
+			DepositRoot: depositRoot,
+			BlockHash:   genesisBlockHash[:],
+		},
+		genesisTime: genesisTime,
+	}, nil
+}
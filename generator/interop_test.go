@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/core"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+	"github.com/ethpandaops/eth-beacon-genesis/validators"
+)
+
+// TestInteropGenesis checks the interop conventions applied to the genesis state. It does not compare the state to
+// client generated interop genesis states.
+func TestInteropGenesis(t *testing.T) {
+	clValidators, err := validators.GenerateInteropValidators(0, 64)
+	if err != nil {
+		t.Fatalf("failed to generate interop validators: %v", err)
+	}
+
+	interopGenesis := &InteropGenesis{
+		GenesisTime:  1700000000,
+		DepositRoot:  phase0.Root{0x01, 0x02},
+		DepositCount: 64,
+	}
+
+	for _, overrides := range [][]string{
+		nil,
+		{"ALTAIR_FORK_EPOCH=0", "BELLATRIX_FORK_EPOCH=0", "CAPELLA_FORK_EPOCH=0", "DENEB_FORK_EPOCH=0", "ELECTRA_FORK_EPOCH=0"},
+	} {
+		clConfig, err := config.LoadNetworkConfig("minimal", &config.LoadOptions{Overrides: overrides})
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+
+		builder := NewGenesisBuilder(core.DeveloperGenesisBlock(30_000_000, nil), clConfig)
+		builder.AddValidators(clValidators)
+		builder.SetInteropGenesis(interopGenesis)
+
+		state, err := builder.BuildState()
+		if err != nil {
+			t.Fatalf("failed to build genesis state: %v", err)
+		}
+
+		fields, err := getRetimeFields(state)
+		if err != nil {
+			t.Fatalf("failed to get state fields: %v", err)
+		}
+
+		if *fields.genesisTime != interopGenesis.GenesisTime {
+			t.Fatalf("%v: unexpected genesis time %v", state.Version, *fields.genesisTime)
+		}
+
+		if phase0.Hash32(fields.eth1Data.BlockHash) != InteropEth1BlockHash || fields.randaoMixes[0] != phase0.Root(InteropEth1BlockHash) {
+			t.Fatalf("%v: unexpected eth1 block hash %#x", state.Version, fields.eth1Data.BlockHash)
+		}
+
+		if fields.eth1Data.DepositRoot != interopGenesis.DepositRoot || fields.eth1Data.DepositCount != interopGenesis.DepositCount {
+			t.Fatalf("%v: unexpected eth1 data %+v", state.Version, fields.eth1Data)
+		}
+
+		switch state.Version {
+		case spec.DataVersionPhase0:
+			if state.Phase0.ETH1DepositIndex != interopGenesis.DepositCount {
+				t.Fatalf("unexpected eth1 deposit index %v", state.Phase0.ETH1DepositIndex)
+			}
+		case spec.DataVersionElectra:
+			if state.Electra.ETH1DepositIndex != interopGenesis.DepositCount || state.Electra.DepositRequestsStartIndex != unsetDepositRequestsStartIndex {
+				t.Fatalf("unexpected deposit indexes %v, %v", state.Electra.ETH1DepositIndex, state.Electra.DepositRequestsStartIndex)
+			}
+		default:
+			t.Fatalf("unexpected genesis state version %v", state.Version)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+)
+
+// farFutureEpoch is the epoch of unscheduled forks.
+const farFutureEpoch = math.MaxUint64
+
+// LintSeverity is the severity of a genesis lint issue.
+type LintSeverity int
+
+const (
+	// LintWarning is an issue the network can run with, but likely not as intended.
+	LintWarning LintSeverity = iota + 1
+	// LintError is an issue the clients reject or the network cannot run properly with.
+	LintError
+)
+
+func (s LintSeverity) String() string {
+	switch s {
+	case LintWarning:
+		return "warning"
+	case LintError:
+		return "error"
+	default:
+		return "none"
+	}
+}
+
+// ParseLintSeverity parses a lint severity name (none, warning or error).
+func ParseLintSeverity(name string) (LintSeverity, error) {
+	switch name {
+	case "none", "":
+		return 0, nil
+	case "warning":
+		return LintWarning, nil
+	case "error":
+		return LintError, nil
+	default:
+		return 0, fmt.Errorf("invalid lint severity '%v' (none, warning or error)", name)
+	}
+}
+
+// LintIssue is an issue found by the genesis linter.
+type LintIssue struct {
+	Severity LintSeverity
+	// Check is the name of the check that found the issue.
+	Check   string
+	Message string
+}
+
+func (i *LintIssue) String() string {
+	return fmt.Sprintf("%v [%v]: %v", i.Severity, i.Check, i.Message)
+}
+
+// LintGenesis checks the consensus config and the validator set of a genesis state for issues that do not prevent
+// building the state, but likely break the network or do not behave as intended.
+func LintGenesis(clConfig *config.Config, state *spec.VersionedBeaconState) ([]*LintIssue, error) {
+	issues := lintForks(clConfig)
+
+	validators, err := state.Validators()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validators: %w", err)
+	}
+
+	activeCount := uint64(0)
+
+	for _, validator := range validators {
+		if validator.ActivationEpoch == 0 {
+			activeCount++
+		}
+	}
+
+	return append(issues, lintValidatorCount(clConfig, activeCount)...), nil
+}
+
+// lintForks checks that the scheduled forks are in order and that the fork versions are unique.
+func lintForks(clConfig *config.Config) []*LintIssue {
+	issues := []*LintIssue{}
+
+	var (
+		prevFork  = config.ForkPhase0
+		prevEpoch = uint64(0)
+		gapFork   = ""
+	)
+
+	for _, fork := range config.Forks[1:] {
+		epoch, ok := clConfig.GetUint(strings.ToUpper(fork) + "_FORK_EPOCH")
+		if !ok || epoch == farFutureEpoch {
+			if gapFork == "" {
+				gapFork = fork
+			}
+
+			continue
+		}
+
+		switch {
+		case gapFork != "":
+			issues = append(issues, &LintIssue{
+				Severity: LintError,
+				Check:    "fork-order",
+				Message:  fmt.Sprintf("%v is scheduled at epoch %v, but the earlier fork %v is not scheduled", fork, epoch, gapFork),
+			})
+		case epoch < prevEpoch:
+			issues = append(issues, &LintIssue{
+				Severity: LintError,
+				Check:    "fork-order",
+				Message:  fmt.Sprintf("%v is scheduled at epoch %v, before the earlier fork %v at epoch %v", fork, epoch, prevFork, prevEpoch),
+			})
+		}
+
+		prevFork = fork
+		prevEpoch = epoch
+	}
+
+	versionForks := map[string]string{}
+
+	for _, fork := range config.Forks {
+		versionKey := strings.ToUpper(fork) + "_FORK_VERSION"
+		if fork == config.ForkPhase0 {
+			versionKey = "GENESIS_FORK_VERSION"
+		}
+
+		version, ok := clConfig.GetBytes(versionKey)
+		if !ok {
+			continue
+		}
+
+		versionHex := fmt.Sprintf("0x%x", version)
+
+		if otherFork, exists := versionForks[versionHex]; exists {
+			issues = append(issues, &LintIssue{
+				Severity: LintError,
+				Check:    "fork-version",
+				Message:  fmt.Sprintf("fork version %v of %v is already used by %v", versionHex, fork, otherFork),
+			})
+
+			continue
+		}
+
+		versionForks[versionHex] = fork
+	}
+
+	return issues
+}
+
+// lintValidatorCount checks that the number of active genesis validators suffices for the committees.
+func lintValidatorCount(clConfig *config.Config, activeCount uint64) []*LintIssue {
+	issues := []*LintIssue{}
+
+	if minCount := clConfig.GetUintOrDefault("MIN_GENESIS_ACTIVE_VALIDATOR_COUNT"); activeCount < minCount {
+		issues = append(issues, &LintIssue{
+			Severity: LintWarning,
+			Check:    "min-genesis-validators",
+			Message:  fmt.Sprintf("%v active validators, less than MIN_GENESIS_ACTIVE_VALIDATOR_COUNT %v", activeCount, minCount),
+		})
+	}
+
+	slotsPerEpoch := clConfig.GetUintOrDefault("SLOTS_PER_EPOCH")
+	targetCommitteeSize := clConfig.GetUintOrDefault("TARGET_COMMITTEE_SIZE")
+
+	switch {
+	case activeCount < slotsPerEpoch:
+		issues = append(issues, &LintIssue{
+			Severity: LintError,
+			Check:    "committees",
+			Message:  fmt.Sprintf("%v active validators cannot fill the committees of all %v slots per epoch", activeCount, slotsPerEpoch),
+		})
+	case activeCount < slotsPerEpoch*targetCommitteeSize:
+		issues = append(issues, &LintIssue{
+			Severity: LintWarning,
+			Check:    "committees",
+			Message: fmt.Sprintf("%v active validators cannot fill a committee of TARGET_COMMITTEE_SIZE %v for each of the %v slots per epoch (%v validators needed)",
+				activeCount, targetCommitteeSize, slotsPerEpoch, slotsPerEpoch*targetCommitteeSize),
+		})
+	}
+
+	altairEpoch, ok := clConfig.GetUint("ALTAIR_FORK_EPOCH")
+	syncCommitteeSize := clConfig.GetUintOrDefault("SYNC_COMMITTEE_SIZE")
+
+	if ok && altairEpoch != farFutureEpoch && activeCount < syncCommitteeSize {
+		issues = append(issues, &LintIssue{
+			Severity: LintWarning,
+			Check:    "sync-committee",
+			Message:  fmt.Sprintf("%v active validators, less than SYNC_COMMITTEE_SIZE %v (validators are selected multiple times)", activeCount, syncCommitteeSize),
+		})
+	}
+
+	return issues
+}
+
+// MaxLintSeverity returns the highest severity of the lint issues (0 if there are no issues).
+func MaxLintSeverity(issues []*LintIssue) LintSeverity {
+	maxSeverity := LintSeverity(0)
+
+	for _, issue := range issues {
+		maxSeverity = max(maxSeverity, issue.Severity)
+	}
+
+	return maxSeverity
+}
@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+	"github.com/ethpandaops/eth-beacon-genesis/validators"
+)
+
+func lintChecks(issues []*LintIssue) string {
+	checks := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		checks = append(checks, issue.Severity.String()+" "+issue.Check)
+	}
+
+	return strings.Join(checks, ", ")
+}
+
+func TestLintGenesis(t *testing.T) {
+	clConfig, err := config.LoadNetworkConfig("minimal", &config.LoadOptions{
+		Overrides: []string{"ALTAIR_FORK_EPOCH=0", "MIN_GENESIS_ACTIVE_VALIDATOR_COUNT=16"},
+	})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	clValidators, err := validators.GenerateInteropValidators(0, 40)
+	if err != nil {
+		t.Fatalf("failed to generate interop validators: %v", err)
+	}
+
+	builder := NewGenesisBuilder(core.DeveloperGenesisBlock(30_000_000, nil), clConfig)
+	builder.AddValidators(clValidators)
+
+	state, err := builder.BuildState()
+	if err != nil {
+		t.Fatalf("failed to build genesis state: %v", err)
+	}
+
+	issues, err := LintGenesis(clConfig, state)
+	if err != nil {
+		t.Fatalf("failed to lint genesis: %v", err)
+	}
+
+	if len(issues) != 0 {
+		t.Fatalf("unexpected lint issues: %v", lintChecks(issues))
+	}
+
+	clValidators[0].Balance = new(uint64)
+
+	builder = NewGenesisBuilder(core.DeveloperGenesisBlock(30_000_000, nil), clConfig)
+	builder.AddValidators(clValidators[:31])
+
+	state, err = builder.BuildState()
+	if err != nil {
+		t.Fatalf("failed to build genesis state: %v", err)
+	}
+
+	issues, err = LintGenesis(clConfig, state)
+	if err != nil {
+		t.Fatalf("failed to lint genesis: %v", err)
+	}
+
+	// 30 active validators (the first validator has no balance)
+	if checks := lintChecks(issues); checks != "warning committees, warning sync-committee" {
+		t.Fatalf("unexpected lint issues: %v", checks)
+	}
+
+	if !strings.Contains(issues[1].Message, "30 active validators") || MaxLintSeverity(issues) != LintWarning {
+		t.Fatalf("unexpected lint issue: %v", issues[1])
+	}
+}
+
+func TestLintValidatorCount(t *testing.T) {
+	clConfig, err := config.LoadNetworkConfig("mainnet", &config.LoadOptions{})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	tests := []struct {
+		activeCount uint64
+		checks      string
+	}{
+		{20000, ""},
+		{3000, "warning min-genesis-validators, warning committees"},
+		{500, "warning min-genesis-validators, warning committees, warning sync-committee"},
+		{16, "warning min-genesis-validators, error committees, warning sync-committee"},
+	}
+
+	for _, test := range tests {
+		if checks := lintChecks(lintValidatorCount(clConfig, test.activeCount)); checks != test.checks {
+			t.Fatalf("%v validators: unexpected lint issues %v, expected %v", test.activeCount, checks, test.checks)
+		}
+	}
+}
+
+func TestLintForks(t *testing.T) {
+	tests := []struct {
+		overrides []string
+		checks    string
+		message   string
+	}{
+		{nil, "", ""},
+		{[]string{"DENEB_FORK_EPOCH=10", "CAPELLA_FORK_EPOCH=20"}, "error fork-order, error fork-order", "capella is scheduled at epoch 20, before the earlier fork bellatrix at epoch 144896"},
+		{[]string{"ELECTRA_FORK_EPOCH=18446744073709551615"}, "error fork-order", "fulu is scheduled at epoch 411392, but the earlier fork electra is not scheduled"},
+		{[]string{"GLOAS_FORK_VERSION=0x01000000"}, "error fork-version", "fork version 0x01000000 of gloas is already used by altair"},
+	}
+
+	for _, test := range tests {
+		clConfig, err := config.LoadNetworkConfig("mainnet", &config.LoadOptions{Overrides: test.overrides})
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+
+		issues := lintForks(clConfig)
+		if checks := lintChecks(issues); checks != test.checks {
+			t.Fatalf("%v: unexpected lint issues %v, expected %v", test.overrides, checks, test.checks)
+		}
+
+		if len(issues) > 0 && issues[0].Message != test.message {
+			t.Fatalf("%v: unexpected lint message: %v", test.overrides, issues[0].Message)
+		}
+	}
+
+	if _, err := ParseLintSeverity("fatal"); err == nil {
+		t.Fatalf("expected invalid lint severity error")
+	}
+}
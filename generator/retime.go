@@ -0,0 +1,212 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+	"github.com/ethpandaops/eth-beacon-genesis/eth1"
+	"github.com/ethpandaops/eth-beacon-genesis/utils"
+)
+
+// RetimeOptions are the changes applied to an existing genesis state by RetimeGenesis.
+type RetimeOptions struct {
+	// GenesisTime is the new genesis time (0 keeps the genesis time of the state).
+	GenesisTime uint64
+	// UpdateForkVersions sets the fork versions of the state to the fork versions of the config.
+	UpdateForkVersions bool
+	// ExecutionGenesis is the execution genesis the state was built from. If the genesis time changes, its timestamp
+	// (and time based forks) is moved by the same offset as the genesis time, and the execution payload header and
+	// the eth1 block hash references (eth1 data, randao mixes and the sync committees derived from them) are updated.
+	// The execution genesis is modified in place and left unchanged if the genesis time does not change.
+	ExecutionGenesis *core.Genesis
+}
+
+// retimeFields references the fields of a genesis state changed by RetimeGenesis.
+type retimeFields struct {
+	genesisTime          *uint64
+	fork                 **phase0.Fork
+	eth1Data             *phase0.ETH1Data
+	randaoMixes          []phase0.Root
+	validators           []*phase0.Validator
+	currentSyncCommittee **altair.SyncCommittee
+	nextSyncCommittee    **altair.SyncCommittee
+	payloadTimestamp     *uint64
+	payloadBlockHash     *phase0.Hash32
+}
+
+func getRetimeFields(state *spec.VersionedBeaconState) (*retimeFields, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		s := state.Phase0
+
+		return &retimeFields{
+			genesisTime: &s.GenesisTime, fork: &s.Fork, eth1Data: s.ETH1Data, randaoMixes: s.RANDAOMixes, validators: s.Validators,
+		}, nil
+	case spec.DataVersionAltair:
+		s := state.Altair
+
+		return &retimeFields{
+			genesisTime: &s.GenesisTime, fork: &s.Fork, eth1Data: s.ETH1Data, randaoMixes: s.RANDAOMixes, validators: s.Validators,
+			currentSyncCommittee: &s.CurrentSyncCommittee, nextSyncCommittee: &s.NextSyncCommittee,
+		}, nil
+	case spec.DataVersionBellatrix:
+		s := state.Bellatrix
+
+		return &retimeFields{
+			genesisTime: &s.GenesisTime, fork: &s.Fork, eth1Data: s.ETH1Data, randaoMixes: s.RANDAOMixes, validators: s.Validators,
+			currentSyncCommittee: &s.CurrentSyncCommittee, nextSyncCommittee: &s.NextSyncCommittee,
+			payloadTimestamp: &s.LatestExecutionPayloadHeader.Timestamp, payloadBlockHash: &s.LatestExecutionPayloadHeader.BlockHash,
+		}, nil
+	case spec.DataVersionCapella:
+		s := state.Capella
+
+		return &retimeFields{
+			genesisTime: &s.GenesisTime, fork: &s.Fork, eth1Data: s.ETH1Data, randaoMixes: s.RANDAOMixes, validators: s.Validators,
+			currentSyncCommittee: &s.CurrentSyncCommittee, nextSyncCommittee: &s.NextSyncCommittee,
+			payloadTimestamp: &s.LatestExecutionPayloadHeader.Timestamp, payloadBlockHash: &s.LatestExecutionPayloadHeader.BlockHash,
+		}, nil
+	case spec.DataVersionDeneb:
+		s := state.Deneb
+
+		return &retimeFields{
+			genesisTime: &s.GenesisTime, fork: &s.Fork, eth1Data: s.ETH1Data, randaoMixes: s.RANDAOMixes, validators: s.Validators,
+			currentSyncCommittee: &s.CurrentSyncCommittee, nextSyncCommittee: &s.NextSyncCommittee,
+			payloadTimestamp: &s.LatestExecutionPayloadHeader.Timestamp, payloadBlockHash: &s.LatestExecutionPayloadHeader.BlockHash,
+		}, nil
+	case spec.DataVersionElectra:
+		s := state.Electra
+
+		return &retimeFields{
+			genesisTime: &s.GenesisTime, fork: &s.Fork, eth1Data: s.ETH1Data, randaoMixes: s.RANDAOMixes, validators: s.Validators,
+			currentSyncCommittee: &s.CurrentSyncCommittee, nextSyncCommittee: &s.NextSyncCommittee,
+			payloadTimestamp: &s.LatestExecutionPayloadHeader.Timestamp, payloadBlockHash: &s.LatestExecutionPayloadHeader.BlockHash,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported version: %s", state.Version)
+	}
+}
+
+// StateGenesisTime returns the genesis time of a genesis state.
+func StateGenesisTime(state *spec.VersionedBeaconState) (uint64, error) {
+	fields, err := getRetimeFields(state)
+	if err != nil {
+		return 0, err
+	}
+
+	return *fields.genesisTime, nil
+}
+
+// RetimeGenesis changes the genesis time, fork versions and execution genesis block of an existing genesis state
+// without rebuilding the validator set. Only the fields depending on the changed values are updated.
+func RetimeGenesis(clConfig *config.Config, state *spec.VersionedBeaconState, options *RetimeOptions) error {
+	fields, err := getRetimeFields(state)
+	if err != nil {
+		return err
+	}
+
+	oldGenesisTime := *fields.genesisTime
+
+	if options.GenesisTime != 0 {
+		logrus.Infof("genesis time: %v -> %v", *fields.genesisTime, options.GenesisTime)
+
+		*fields.genesisTime = options.GenesisTime
+	}
+
+	if options.UpdateForkVersions {
+		fork := GetStateForkConfig(state.Version, clConfig)
+
+		logrus.Infof("fork version: 0x%x -> 0x%x", (*fields.fork).CurrentVersion, fork.CurrentVersion)
+
+		*fields.fork = fork
+	}
+
+	if options.ExecutionGenesis != nil && *fields.genesisTime != oldGenesisTime {
+		if err := retimeExecutionGenesis(clConfig, fields, options.ExecutionGenesis, oldGenesisTime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func retimeExecutionGenesis(clConfig *config.Config, fields *retimeFields, elGenesis *core.Genesis, oldGenesisTime uint64) error {
+	oldBlock := elGenesis.ToBlock()
+
+	// the execution genesis keeps its offset to the genesis time
+	newGenesisTime := *fields.genesisTime
+	if newGenesisTime < oldGenesisTime && oldGenesisTime-newGenesisTime > elGenesis.Timestamp {
+		return fmt.Errorf("cannot move execution genesis timestamp %v back by %v seconds", elGenesis.Timestamp, oldGenesisTime-newGenesisTime)
+	}
+
+	timestamp := elGenesis.Timestamp + newGenesisTime - oldGenesisTime
+
+	logrus.Infof("execution genesis timestamp: %v -> %v", elGenesis.Timestamp, timestamp)
+
+	eth1.SetGenesisTimestamp(elGenesis, timestamp)
+
+	return retimeGenesisBlock(clConfig, fields, oldBlock, elGenesis.ToBlock())
+}
+
+func retimeGenesisBlock(clConfig *config.Config, fields *retimeFields, oldBlock, newBlock *types.Block) error {
+	oldHash := phase0.Hash32(oldBlock.Hash())
+	newHash := phase0.Hash32(newBlock.Hash())
+
+	if fields.payloadBlockHash != nil {
+		if *fields.payloadBlockHash != oldHash {
+			return fmt.Errorf("execution genesis block %#x does not match the execution payload header of the state (%#x)", oldHash, *fields.payloadBlockHash)
+		}
+
+		*fields.payloadBlockHash = newHash
+		*fields.payloadTimestamp = newBlock.Time()
+
+		logrus.Infof("execution payload header: block hash %#x, timestamp %v", newHash, newBlock.Time())
+	}
+
+	// the eth1 block hash is only referenced by states built from the execution genesis (not by interop or shadow fork states)
+	if phase0.Hash32(fields.eth1Data.BlockHash) != oldHash {
+		return nil
+	}
+
+	fields.eth1Data.BlockHash = newHash[:]
+
+	for i := range fields.randaoMixes {
+		if fields.randaoMixes[i] == phase0.Root(oldHash) {
+			fields.randaoMixes[i] = phase0.Root(newHash)
+		}
+	}
+
+	logrus.Infof("eth1 block hash: %#x -> %#x", oldHash, newHash)
+
+	if fields.currentSyncCommittee == nil {
+		return nil
+	}
+
+	// the genesis sync committees are derived from the randao mix
+	syncCommittee, err := utils.GetGenesisSyncCommittee(clConfig, fields.validators, newHash)
+	if err != nil {
+		return fmt.Errorf("failed to get genesis sync committee: %w", err)
+	}
+
+	*fields.currentSyncCommittee = syncCommittee
+	*fields.nextSyncCommittee = syncCommittee
+
+	return nil
+}
+
+// SerializeState serializes a genesis state with the SSZ types of the config.
+func SerializeState(clConfig *config.Config, state *spec.VersionedBeaconState, contentType http.ContentType) ([]byte, error) {
+	forkConfig := GetForkConfig(state.Version)
+	if forkConfig == nil {
+		return nil, fmt.Errorf("unsupported version: %s", state.Version)
+	}
+
+	return forkConfig.BuilderFn(nil, clConfig).Serialize(state, contentType)
+}
@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+	"github.com/ethpandaops/eth-beacon-genesis/validators"
+)
+
+func buildTestGenesis(t *testing.T, clConfig *config.Config, elGenesis *core.Genesis, genesisTime uint64) *spec.VersionedBeaconState {
+	t.Helper()
+
+	clValidators, err := validators.GenerateInteropValidators(0, 64)
+	if err != nil {
+		t.Fatalf("failed to generate interop validators: %v", err)
+	}
+
+	builder := NewGenesisBuilder(elGenesis, clConfig)
+	builder.AddValidators(clValidators)
+	builder.SetGenesisTime(genesisTime)
+
+	state, err := builder.BuildState()
+	if err != nil {
+		t.Fatalf("failed to build genesis state: %v", err)
+	}
+
+	return state
+}
+
+func TestRetimeGenesis(t *testing.T) {
+	clConfig, err := config.LoadNetworkConfig("hoodi", &config.LoadOptions{})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	const newGenesisTime = 1800000000
+
+	state := buildTestGenesis(t, clConfig, core.DefaultHoodiGenesisBlock(), 0)
+
+	oldGenesisTime, err := StateGenesisTime(state)
+	if err != nil {
+		t.Fatalf("failed to get genesis time: %v", err)
+	}
+
+	elGenesis := core.DefaultHoodiGenesisBlock()
+	if err := RetimeGenesis(clConfig, state, &RetimeOptions{GenesisTime: newGenesisTime, ExecutionGenesis: elGenesis}); err != nil {
+		t.Fatalf("failed to retime genesis: %v", err)
+	}
+
+	if genesisTime, _ := StateGenesisTime(state); genesisTime != newGenesisTime {
+		t.Fatalf("unexpected genesis time %v", genesisTime)
+	}
+
+	// the execution genesis keeps its offset to the genesis time
+	expectedTimestamp := core.DefaultHoodiGenesisBlock().Timestamp + newGenesisTime - oldGenesisTime
+	if elGenesis.Timestamp != expectedTimestamp || state.Deneb.LatestExecutionPayloadHeader.Timestamp != expectedTimestamp {
+		t.Fatalf("unexpected execution genesis timestamp %v, payload timestamp %v, expected %v",
+			elGenesis.Timestamp, state.Deneb.LatestExecutionPayloadHeader.Timestamp, expectedTimestamp)
+	}
+
+	// the retimed state must match a state built from scratch with the retimed execution genesis
+	retimedRoot, retimedHash, err := genesisStateRoot(clConfig, state)
+	if err != nil {
+		t.Fatalf("failed to get retimed state root: %v", err)
+	}
+
+	expectedRoot, expectedHash, err := genesisStateRoot(clConfig, buildTestGenesis(t, clConfig, elGenesis, newGenesisTime))
+	if err != nil {
+		t.Fatalf("failed to get rebuilt state root: %v", err)
+	}
+
+	if retimedHash != elGenesis.ToBlock().Hash() || retimedHash != expectedHash {
+		t.Fatalf("unexpected execution block hash %v, expected %v", retimedHash, elGenesis.ToBlock().Hash())
+	}
+
+	if retimedRoot != expectedRoot {
+		t.Fatalf("retimed state root %#x does not match rebuilt state root %#x", retimedRoot, expectedRoot)
+	}
+
+	// the execution genesis must match the execution payload header of the state
+	err = RetimeGenesis(clConfig, state, &RetimeOptions{GenesisTime: oldGenesisTime, ExecutionGenesis: core.DefaultHoodiGenesisBlock()})
+	if err == nil || !strings.Contains(err.Error(), "does not match the execution payload header") {
+		t.Fatalf("expected execution genesis mismatch error, got %v", err)
+	}
+}
+
+func TestRetimeGenesisUnchangedTime(t *testing.T) {
+	clConfig, err := config.LoadNetworkConfig("hoodi", &config.LoadOptions{})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	state := buildTestGenesis(t, clConfig, core.DefaultHoodiGenesisBlock(), 0)
+
+	genesisTime, err := StateGenesisTime(state)
+	if err != nil {
+		t.Fatalf("failed to get genesis time: %v", err)
+	}
+
+	expectedRoot, _, err := genesisStateRoot(clConfig, state)
+	if err != nil {
+		t.Fatalf("failed to get state root: %v", err)
+	}
+
+	// without a new genesis time (or with the genesis time of the state) the execution genesis is left alone
+	for _, newGenesisTime := range []uint64{0, genesisTime} {
+		elGenesis := core.DefaultHoodiGenesisBlock()
+		if err := RetimeGenesis(clConfig, state, &RetimeOptions{GenesisTime: newGenesisTime, ExecutionGenesis: elGenesis}); err != nil {
+			t.Fatalf("failed to retime genesis: %v", err)
+		}
+
+		if elGenesis.Timestamp != core.DefaultHoodiGenesisBlock().Timestamp || elGenesis.ToBlock().Hash() != params.HoodiGenesisHash {
+			t.Fatalf("execution genesis changed: timestamp %v, block hash %v", elGenesis.Timestamp, elGenesis.ToBlock().Hash())
+		}
+
+		stateRoot, blockHash, err := genesisStateRoot(clConfig, state)
+		if err != nil {
+			t.Fatalf("failed to get state root: %v", err)
+		}
+
+		if stateRoot != expectedRoot || blockHash != params.HoodiGenesisHash {
+			t.Fatalf("genesis state changed: state root %#x, block hash %v", stateRoot, blockHash)
+		}
+	}
+}
+
+func TestRetimeGenesisEarlierTime(t *testing.T) {
+	clConfig, err := config.LoadNetworkConfig("minimal", &config.LoadOptions{})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	elGenesis := core.DeveloperGenesisBlock(30_000_000, nil)
+	elGenesis.Timestamp = 1700000000
+
+	state := buildTestGenesis(t, clConfig, elGenesis, 1700000600)
+
+	if err := RetimeGenesis(clConfig, state, &RetimeOptions{GenesisTime: 1600000600, ExecutionGenesis: elGenesis}); err != nil {
+		t.Fatalf("failed to retime genesis: %v", err)
+	}
+
+	if elGenesis.Timestamp != 1600000000 {
+		t.Fatalf("unexpected execution genesis timestamp %v", elGenesis.Timestamp)
+	}
+
+	err = RetimeGenesis(clConfig, state, &RetimeOptions{GenesisTime: 1, ExecutionGenesis: elGenesis})
+	if err == nil || !strings.Contains(err.Error(), "cannot move execution genesis timestamp") {
+		t.Fatalf("expected execution genesis timestamp error, got %v", err)
+	}
+}
+
+func TestRetimeGenesisForkVersions(t *testing.T) {
+	clConfig, err := config.LoadNetworkConfig("minimal", &config.LoadOptions{})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	newConfig, err := config.LoadNetworkConfig("minimal", &config.LoadOptions{Overrides: []string{"GENESIS_FORK_VERSION=0x10000001"}})
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	elGenesis := core.DeveloperGenesisBlock(30_000_000, nil)
+	state := buildTestGenesis(t, clConfig, elGenesis, 0)
+
+	if err := RetimeGenesis(newConfig, state, &RetimeOptions{GenesisTime: 1800000000, UpdateForkVersions: true}); err != nil {
+		t.Fatalf("failed to retime genesis: %v", err)
+	}
+
+	if state.Phase0.Fork.CurrentVersion != [4]byte{0x10, 0x00, 0x00, 0x01} || state.Phase0.Fork.PreviousVersion != state.Phase0.Fork.CurrentVersion {
+		t.Fatalf("unexpected fork %+v", state.Phase0.Fork)
+	}
+
+	retimedRoot, _, err := genesisStateRoot(newConfig, state)
+	if err != nil {
+		t.Fatalf("failed to get retimed state root: %v", err)
+	}
+
+	expectedRoot, _, err := genesisStateRoot(newConfig, buildTestGenesis(t, newConfig, elGenesis, 1800000000))
+	if err != nil {
+		t.Fatalf("failed to get rebuilt state root: %v", err)
+	}
+
+	if retimedRoot != expectedRoot {
+		t.Fatalf("retimed state root %#x does not match rebuilt state root %#x", retimedRoot, expectedRoot)
+	}
+}
@@ -19,3 +19,26 @@ func ComputeDepositRoot(config *config.Config) (phase0.Root, error) {
 
 	return phase0.Root(depositRoot), nil
 }
+
+// ComputeDepositDataRoot computes the SSZ hash-tree-root of a deposit data list,
+// which is the deposit_root of the eth1 data after processing the deposits at genesis.
+func ComputeDepositDataRoot(config *config.Config, depositData []*phase0.DepositData) (phase0.Root, error) {
+	maxDeposits := uint64(1) << config.GetUintDefault("DEPOSIT_CONTRACT_TREE_DEPTH", 32)
+
+	depositRoot, err := HashWithFastSSZHasher(func(hh *ssz.Hasher) error {
+		for _, elem := range depositData {
+			if err := elem.HashTreeRootWith(hh); err != nil {
+				return err
+			}
+		}
+
+		hh.MerkleizeWithMixin(0, uint64(len(depositData)), maxDeposits)
+
+		return nil
+	})
+	if err != nil {
+		return phase0.Root{}, err
+	}
+
+	return phase0.Root(depositRoot), nil
+}
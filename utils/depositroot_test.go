@@ -2,12 +2,14 @@ package utils
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/eth-beacon-genesis/config"
 	"gopkg.in/yaml.v3"
 )
@@ -106,3 +108,54 @@ func TestComputeDepositRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeDepositDataRoot(t *testing.T) {
+	cfg := createTestConfig(t, "minimal", map[string]interface{}{
+		"DEPOSIT_CONTRACT_TREE_DEPTH": uint64(32),
+	})
+
+	root, err := ComputeDepositDataRoot(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if hex.EncodeToString(root[:]) != "d70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e" {
+		t.Fatalf("empty deposit data root mismatch: got %x", root)
+	}
+
+	depositData := &phase0.DepositData{
+		PublicKey:             phase0.BLSPubKey{0x80},
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                32000000000,
+	}
+
+	root, err = ComputeDepositDataRoot(cfg, []*phase0.DepositData{depositData})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// compute the expected root manually: merkle branch with zero hashes, mixed with the list length
+	leaf, err := depositData.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("failed to compute deposit data root: %v", err)
+	}
+
+	node := leaf[:]
+	zeroHash := make([]byte, 32)
+
+	for i := 0; i < 32; i++ {
+		nodeHash := sha256.Sum256(append(append([]byte{}, node...), zeroHash...))
+		node = nodeHash[:]
+
+		zeroHashNext := sha256.Sum256(append(append([]byte{}, zeroHash...), zeroHash...))
+		zeroHash = zeroHashNext[:]
+	}
+
+	length := make([]byte, 32)
+	length[0] = 1
+	expectedRoot := sha256.Sum256(append(node, length...))
+
+	if !bytes.Equal(root[:], expectedRoot[:]) {
+		t.Fatalf("deposit data root mismatch: got %x, want %x", root, expectedRoot)
+	}
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+)
+
+// ComputeForkDigest computes the fork digest at the given epoch (compute_fork_digest in the fulu spec).
+// From fulu on, the digest also commits to the blob parameters in effect at the epoch.
+func ComputeForkDigest(config *config.Config, genesisValidatorsRoot phase0.Root, epoch uint64) (phase0.ForkDigest, error) {
+	forkData := &phase0.ForkData{
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
+	copy(forkData.CurrentVersion[:], config.GetForkVersion(epoch))
+
+	baseDigest, err := forkData.HashTreeRoot()
+	if err != nil {
+		return phase0.ForkDigest{}, fmt.Errorf("failed to compute fork data root: %w", err)
+	}
+
+	if fuluEpoch, ok := config.GetUint("FULU_FORK_EPOCH"); ok && epoch >= fuluEpoch {
+		blobParameters := config.GetBlobParameters(epoch)
+
+		blobData := make([]byte, 16)
+		binary.LittleEndian.PutUint64(blobData[0:8], blobParameters.Epoch)
+		binary.LittleEndian.PutUint64(blobData[8:16], blobParameters.MaxBlobsPerBlock)
+
+		blobHash := sha256.Sum256(blobData)
+		for i := range baseDigest {
+			baseDigest[i] ^= blobHash[i]
+		}
+	}
+
+	var forkDigest phase0.ForkDigest
+
+	copy(forkDigest[:], baseDigest[:4])
+
+	return forkDigest, nil
+}
+
+// GetGenesisValidatorsRoot returns the genesis validators root of a beacon state.
+func GetGenesisValidatorsRoot(state *spec.VersionedBeaconState) (phase0.Root, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0.GenesisValidatorsRoot, nil
+	case spec.DataVersionAltair:
+		return state.Altair.GenesisValidatorsRoot, nil
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.GenesisValidatorsRoot, nil
+	case spec.DataVersionCapella:
+		return state.Capella.GenesisValidatorsRoot, nil
+	case spec.DataVersionDeneb:
+		return state.Deneb.GenesisValidatorsRoot, nil
+	case spec.DataVersionElectra:
+		return state.Electra.GenesisValidatorsRoot, nil
+	default:
+		return phase0.Root{}, fmt.Errorf("unsupported state version %v", state.Version)
+	}
+}
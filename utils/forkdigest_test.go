@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+)
+
+const mainnetForkConfig = `
+PRESET_BASE: mainnet
+GENESIS_FORK_VERSION: 0x00000000
+ALTAIR_FORK_VERSION: 0x01000000
+ALTAIR_FORK_EPOCH: 74240
+BELLATRIX_FORK_VERSION: 0x02000000
+BELLATRIX_FORK_EPOCH: 144896
+CAPELLA_FORK_VERSION: 0x03000000
+CAPELLA_FORK_EPOCH: 194048
+DENEB_FORK_VERSION: 0x04000000
+DENEB_FORK_EPOCH: 269568
+ELECTRA_FORK_VERSION: 0x05000000
+ELECTRA_FORK_EPOCH: 364032
+FULU_FORK_VERSION: 0x06000000
+FULU_FORK_EPOCH: 411392
+MAX_BLOBS_PER_BLOCK_ELECTRA: 9
+BLOB_SCHEDULE:
+  - EPOCH: 419072
+    MAX_BLOBS_PER_BLOCK: 21
+  - EPOCH: 412672
+    MAX_BLOBS_PER_BLOCK: 15
+`
+
+// specForkDigest is an independent transcription of compute_fork_digest of the fulu spec, with the fork data root
+// computed by hand (both fields of ForkData fit in a single chunk) and the blob parameters passed explicitly.
+func specForkDigest(version [4]byte, genesisValidatorsRoot []byte, blobParameters *config.BlobParameters) string {
+	chunks := make([]byte, 64)
+	copy(chunks, version[:])
+	copy(chunks[32:], genesisValidatorsRoot)
+
+	digest := sha256.Sum256(chunks)
+
+	if blobParameters != nil {
+		blobData := binary.LittleEndian.AppendUint64(nil, blobParameters.Epoch)
+		blobData = binary.LittleEndian.AppendUint64(blobData, blobParameters.MaxBlobsPerBlock)
+
+		blobHash := sha256.Sum256(blobData)
+		for i := range digest {
+			digest[i] ^= blobHash[i]
+		}
+	}
+
+	return hex.EncodeToString(digest[:4])
+}
+
+func TestComputeForkDigest(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(mainnetForkConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	genesisValidatorsRoot, _ := hex.DecodeString("4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95")
+
+	// The pre-fulu digests are the known fork digests of mainnet. There are no published fulu and BPO digest vectors
+	// in this tree, so those digests are pinned from specForkDigest with the blob parameters given explicitly: fulu
+	// falls back to the electra blob parameters, the BPO epochs use the blob schedule entries.
+	tests := []struct {
+		epoch          uint64
+		version        [4]byte
+		blobParameters *config.BlobParameters
+		digest         string
+	}{
+		{0, [4]byte{0, 0, 0, 0}, nil, "b5303f2a"},
+		{74240, [4]byte{1, 0, 0, 0}, nil, "afcaaba0"},
+		{144896, [4]byte{2, 0, 0, 0}, nil, "4a26c58b"},
+		{194048, [4]byte{3, 0, 0, 0}, nil, "bba4da96"},
+		{269568, [4]byte{4, 0, 0, 0}, nil, "6a95a1a9"},
+		{364032, [4]byte{5, 0, 0, 0}, nil, "ad532ceb"},
+		{411392, [4]byte{6, 0, 0, 0}, &config.BlobParameters{Epoch: 364032, MaxBlobsPerBlock: 9}, "cc2c5cdb"},
+		{412672, [4]byte{6, 0, 0, 0}, &config.BlobParameters{Epoch: 412672, MaxBlobsPerBlock: 15}, "cb0d1acc"},
+		{419072, [4]byte{6, 0, 0, 0}, &config.BlobParameters{Epoch: 419072, MaxBlobsPerBlock: 21}, "8c9f62fe"},
+	}
+
+	digests := map[string]uint64{}
+
+	for _, test := range tests {
+		if expected := specForkDigest(test.version, genesisValidatorsRoot, test.blobParameters); expected != test.digest {
+			t.Fatalf("epoch %v: reference fork digest %v does not match pinned digest %v", test.epoch, expected, test.digest)
+		}
+
+		// epochs within a fork or blob schedule entry share its digest
+		for _, epoch := range []uint64{test.epoch, test.epoch + 1} {
+			digest, err := ComputeForkDigest(cfg, phase0.Root(genesisValidatorsRoot), epoch)
+			if err != nil {
+				t.Fatalf("epoch %v: unexpected error: %v", epoch, err)
+			}
+
+			if hex.EncodeToString(digest[:]) != test.digest {
+				t.Fatalf("epoch %v: expected fork digest %v, got %x", epoch, test.digest, digest)
+			}
+		}
+
+		// each fork and blob schedule entry must result in a distinct digest
+		if otherEpoch, exists := digests[test.digest]; exists {
+			t.Fatalf("epoch %v: same fork digest as epoch %v", test.epoch, otherEpoch)
+		}
+
+		digests[test.digest] = test.epoch
+	}
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ParseGenesisTime parses a genesis time given as unix timestamp or RFC3339 time.
+func ParseGenesisTime(value string) (uint64, error) {
+	if timestamp, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return timestamp, nil
+	}
+
+	genesisTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid genesis time '%v' (expected unix timestamp or RFC3339 time)", value)
+	}
+
+	if genesisTime.Unix() < 0 {
+		return 0, fmt.Errorf("invalid genesis time '%v' (before 1970)", value)
+	}
+
+	return uint64(genesisTime.Unix()), nil //nolint:gosec // checked above
+}
+
+// AlignGenesisTime rounds the genesis time up to the next multiple of the slot duration,
+// so the slots start at the same offsets in wall clock time as the slots of other networks.
+func AlignGenesisTime(genesisTime, secondsPerSlot uint64) uint64 {
+	if secondsPerSlot == 0 || genesisTime%secondsPerSlot == 0 {
+		return genesisTime
+	}
+
+	return genesisTime + secondsPerSlot - genesisTime%secondsPerSlot
+}
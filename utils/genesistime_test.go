@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGenesisTime(t *testing.T) {
+	tests := []struct {
+		value       string
+		genesisTime uint64
+		err         string
+	}{
+		{"1742213400", 1742213400, ""},
+		{"2025-03-17T12:10:00Z", 1742213400, ""},
+		{"2025-03-17T14:10:00+02:00", 1742213400, ""},
+		{"1969-12-31T23:59:59Z", 0, "before 1970"},
+		{"2025-03-17", 0, "invalid genesis time"},
+		{"-5", 0, "invalid genesis time"},
+	}
+
+	for _, test := range tests {
+		genesisTime, err := ParseGenesisTime(test.value)
+
+		switch {
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Fatalf("%v: expected error containing '%v', got: %v", test.value, test.err, err)
+		case test.err == "" && err != nil:
+			t.Fatalf("%v: unexpected error: %v", test.value, err)
+		case genesisTime != test.genesisTime:
+			t.Fatalf("%v: unexpected genesis time %v, expected %v", test.value, genesisTime, test.genesisTime)
+		}
+	}
+}
+
+func TestAlignGenesisTime(t *testing.T) {
+	tests := []struct {
+		genesisTime    uint64
+		secondsPerSlot uint64
+		aligned        uint64
+	}{
+		{1742213400, 12, 1742213400},
+		{1742213401, 12, 1742213412},
+		{1742213411, 12, 1742213412},
+		{1742213401, 6, 1742213406},
+		{1742213401, 0, 1742213401},
+	}
+
+	for _, test := range tests {
+		if aligned := AlignGenesisTime(test.genesisTime, test.secondsPerSlot); aligned != test.aligned {
+			t.Fatalf("%v/%v: unexpected aligned genesis time %v, expected %v", test.genesisTime, test.secondsPerSlot, aligned, test.aligned)
+		}
+	}
+}
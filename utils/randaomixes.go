@@ -6,7 +6,7 @@ import (
 )
 
 func SeedRandomMixes(genesisBlockHash phase0.Hash32, config *config.Config) []phase0.Root {
-	epochsPerHistoricalVector := config.GetUintDefault("EPOCHS_PER_HISTORICAL_VECTOR", 65536)
+	epochsPerHistoricalVector := config.GetUintOrDefault("EPOCHS_PER_HISTORICAL_VECTOR")
 	randomMixes := make([]phase0.Root, epochsPerHistoricalVector)
 
 	for i := range randomMixes {
@@ -62,9 +62,9 @@ func GetGenesisSyncCommittee(config *config.Config, validators []*phase0.Validat
 //
 // Note: Committee can contain duplicate indices for small validator sets (< SYNC_COMMITTEE_SIZE + 128)
 func computeGenesisSyncCommitteeIndices(config *config.Config, active []phase0.ValidatorIndex, validators []*phase0.Validator, randaoMix phase0.Hash32) []phase0.ValidatorIndex {
-	syncCommitteeSize := config.GetUintDefault("SYNC_COMMITTEE_SIZE", 512)
-	shuffleRoundCount := config.GetUintDefault("SHUFFLE_ROUND_COUNT", 90)
-	maxEffectiveBalance := config.GetUintDefault("MAX_EFFECTIVE_BALANCE", 32000000000)
+	syncCommitteeSize := config.GetUintOrDefault("SYNC_COMMITTEE_SIZE")
+	shuffleRoundCount := config.GetUintOrDefault("SHUFFLE_ROUND_COUNT")
+	maxEffectiveBalance := config.GetUintOrDefault("MAX_EFFECTIVE_BALANCE")
 	domainSyncCommittee := config.GetBytesDefault("DOMAIN_SYNC_COMMITTEE", []byte{0x07, 0x00, 0x00, 0x00})
 	syncCommitteeIndices := make([]phase0.ValidatorIndex, 0, syncCommitteeSize)
 	periodSeed := computeGenesisSeed(randaoMix, 0, phase0.DomainType(domainSyncCommittee))
@@ -111,9 +111,9 @@ func computeGenesisSyncCommitteeIndices(config *config.Config, active []phase0.V
 }
 
 func computeGenesisSyncCommitteeIndicesElectra(config *config.Config, active []phase0.ValidatorIndex, validators []*phase0.Validator, randaoMix phase0.Hash32) []phase0.ValidatorIndex {
-	syncCommitteeSize := config.GetUintDefault("SYNC_COMMITTEE_SIZE", 512)
-	shuffleRoundCount := config.GetUintDefault("SHUFFLE_ROUND_COUNT", 90)
-	maxEffectiveBalance := config.GetUintDefault("MAX_EFFECTIVE_BALANCE", 32000000000)
+	syncCommitteeSize := config.GetUintOrDefault("SYNC_COMMITTEE_SIZE")
+	shuffleRoundCount := config.GetUintOrDefault("SHUFFLE_ROUND_COUNT")
+	maxEffectiveBalance := config.GetUintOrDefault("MAX_EFFECTIVE_BALANCE")
 	domainSyncCommittee := config.GetBytesDefault("DOMAIN_SYNC_COMMITTEE", []byte{0x07, 0x00, 0x00, 0x00})
 	syncCommitteeIndices := make([]phase0.ValidatorIndex, 0, syncCommitteeSize)
 	periodSeed := computeGenesisSeed(randaoMix, 0, phase0.DomainType(domainSyncCommittee))
@@ -16,7 +16,7 @@ func ComputeTransactionsRoot(transactions types.Transactions, config *config.Con
 	// since that is what we put as transactions_root in the CL execution-payload.
 	// Not to be confused with the legacy MPT root in the EL block header.
 	num := uint64(len(transactions))
-	maxTransactionsPerPayload := config.GetUintDefault("MAX_TRANSACTIONS_PER_PAYLOAD", 1048576)
+	maxTransactionsPerPayload := config.GetUintOrDefault("MAX_TRANSACTIONS_PER_PAYLOAD")
 
 	if num > maxTransactionsPerPayload {
 		return phase0.Root{}, fmt.Errorf("transactions list is too long")
@@ -33,7 +33,7 @@ func ComputeTransactionsRoot(transactions types.Transactions, config *config.Con
 		clTransactions[i] = opaqueTx
 	}
 
-	maxBytesPerTx := config.GetUintDefault("MAX_BYTES_PER_TRANSACTION", 1073741824)
+	maxBytesPerTx := config.GetUintOrDefault("MAX_BYTES_PER_TRANSACTION")
 
 	transactionsRoot, err := HashWithFastSSZHasher(func(hh *ssz.Hasher) error {
 		for i, elem := range clTransactions {
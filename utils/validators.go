@@ -10,8 +10,9 @@ import (
 
 func GetGenesisValidators(config *config.Config, validators []*validators.Validator) ([]*phase0.Validator, phase0.Root) {
 	// Process activations
-	maxEffectiveBalance := phase0.Gwei(config.GetUintDefault("MAX_EFFECTIVE_BALANCE", 32_000_000_000))
-	maxEffectiveBalanceElectra := phase0.Gwei(config.GetUintDefault("MAX_EFFECTIVE_BALANCE_ELECTRA", 2_048_000_000_000))
+	maxEffectiveBalance := phase0.Gwei(config.GetUintOrDefault("MAX_EFFECTIVE_BALANCE"))
+	maxEffectiveBalanceElectra := phase0.Gwei(config.GetUintOrDefault("MAX_EFFECTIVE_BALANCE_ELECTRA"))
+	effectiveBalanceIncrement := phase0.Gwei(config.GetUintOrDefault("EFFECTIVE_BALANCE_INCREMENT"))
 	isElectraActive := false
 
 	if electraActivationEpoch, ok := config.GetUint("ELECTRA_FORK_EPOCH"); ok && electraActivationEpoch == 0 {
@@ -34,6 +35,9 @@ func GetGenesisValidators(config *config.Config, validators []*validators.Valida
 			effectiveBalance = maxEffectiveBalance
 		}
 
+		// the effective balance is a multiple of EFFECTIVE_BALANCE_INCREMENT (rounded down)
+		effectiveBalance -= effectiveBalance % effectiveBalanceIncrement
+
 		if isElectraActive && val.WithdrawalCredentials[0] == 0x02 {
 			// allow electra validators with 0x02 withdrawal credentials to have a higher max effective balance
 			if effectiveBalance > maxEffectiveBalanceElectra {
@@ -63,7 +67,7 @@ func GetGenesisValidators(config *config.Config, validators []*validators.Valida
 		clValidators = append(clValidators, validator)
 	}
 
-	maxValidators := config.GetUintDefault("VALIDATOR_REGISTRY_LIMIT", 1099511627776)
+	maxValidators := config.GetUintOrDefault("VALIDATOR_REGISTRY_LIMIT")
 	validatorsRoot, err := HashWithFastSSZHasher(func(hh *ssz.Hasher) error {
 		for _, elem := range clValidators {
 			if err := elem.HashTreeRootWith(hh); err != nil {
@@ -84,7 +88,7 @@ func GetGenesisValidators(config *config.Config, validators []*validators.Valida
 }
 
 func GetGenesisBalances(config *config.Config, validators []*validators.Validator) []phase0.Gwei {
-	maxEffectiveBalance := phase0.Gwei(config.GetUintDefault("MAX_EFFECTIVE_BALANCE", 32_000_000_000))
+	maxEffectiveBalance := phase0.Gwei(config.GetUintOrDefault("MAX_EFFECTIVE_BALANCE"))
 	balances := make([]phase0.Gwei, len(validators))
 
 	for i, validator := range validators {
@@ -3,6 +3,9 @@ package utils
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -217,3 +220,49 @@ func TestGetGenesisBalances(t *testing.T) {
 func ptr(v uint64) *uint64 {
 	return &v
 }
+
+func TestGetGenesisValidatorsDistributedBalances(t *testing.T) {
+	list := "balance_distribution:\n  type: uniform\n  seed: 42\n  min: 16000000000\n  max: 40000000000\nvalidators:\n"
+	for i := 1; i <= 32; i++ {
+		list += fmt.Sprintf("  - pubkey: \"0x%x\"\n    withdrawal_credentials: \"0x%x\"\n", makeBytes(48, byte(i)), makeBytes(32, 0))
+	}
+
+	listFile := filepath.Join(t.TempDir(), "validators.yaml")
+	if err := os.WriteFile(listFile, []byte(list), 0o600); err != nil {
+		t.Fatalf("failed to write validators list: %v", err)
+	}
+
+	listValidators, err := validators.LoadValidatorsFromFile(listFile)
+	if err != nil {
+		t.Fatalf("failed to load validators: %v", err)
+	}
+
+	cfg := createTestConfig(t, "minimal", map[string]interface{}{
+		"MAX_EFFECTIVE_BALANCE":    uint64(32_000_000_000),
+		"FAR_FUTURE_EPOCH":         uint64(18446744073709551615),
+		"VALIDATOR_REGISTRY_LIMIT": uint64(1099511627776),
+	})
+
+	genesisValidators, _ := GetGenesisValidators(cfg, listValidators)
+	if len(genesisValidators) != len(listValidators) {
+		t.Fatalf("wrong number of validators: got %v, want %v", len(genesisValidators), len(listValidators))
+	}
+
+	unaligned := 0
+
+	for i, validator := range genesisValidators {
+		balance := *listValidators[i].Balance
+		if balance%1_000_000_000 != 0 {
+			unaligned++
+		}
+
+		expected := min(balance-balance%1_000_000_000, 32_000_000_000)
+		if uint64(validator.EffectiveBalance) != expected {
+			t.Fatalf("validator %v: effective balance %v for balance %v, want %v", i, validator.EffectiveBalance, balance, expected)
+		}
+	}
+
+	if unaligned == 0 {
+		t.Fatalf("expected the distribution to produce balances that are not multiples of EFFECTIVE_BALANCE_INCREMENT")
+	}
+}
@@ -17,7 +17,7 @@ func ComputeWithdrawalsRoot(withdrawals types.Withdrawals, config *config.Config
 	// since that is what we put as withdrawals_root in the CL execution-payload.
 	// Not to be confused with the legacy MPT root in the EL block header.
 	num := uint64(len(withdrawals))
-	maxWithdrawalsPerPayload := config.GetUintDefault("MAX_WITHDRAWALS_PER_PAYLOAD", 16)
+	maxWithdrawalsPerPayload := config.GetUintOrDefault("MAX_WITHDRAWALS_PER_PAYLOAD")
 
 	if num > maxWithdrawalsPerPayload {
 		return phase0.Root{}, fmt.Errorf("withdrawals list is too long")
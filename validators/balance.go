@@ -0,0 +1,200 @@
+package validators
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// balance distribution types
+const (
+	BalanceDistributionUniform = "uniform"
+	BalanceDistributionRamp    = "ramp"
+	BalanceDistributionBuckets = "buckets"
+)
+
+// metadata keys recorded on validators with a distributed balance
+const (
+	BalanceDistributionMetadataKey = "balance_distribution"
+	BalanceSeedMetadataKey         = "balance_seed"
+)
+
+// BalanceDistribution assigns heterogeneous balances to the validators of a source.
+// The balance of each validator is derived from the seed and its index within the source,
+// so the result is reproducible and independent of the processing order.
+type BalanceDistribution struct {
+	// Type is the distribution type (uniform, ramp or buckets). Can be empty if only overrides are used.
+	Type string `yaml:"type"`
+	// Seed for the uniform and buckets distributions. A random seed is generated if not set.
+	Seed *uint64 `yaml:"seed"`
+	// Min and Max are the balance range for the uniform and ramp distributions (inclusive).
+	Min uint64 `yaml:"min"`
+	Max uint64 `yaml:"max"`
+	// Step is the balance granularity for the uniform and ramp distributions (default 1 gwei).
+	Step uint64 `yaml:"step"`
+	// Buckets are the weighted balances for the buckets distribution.
+	Buckets []BalanceBucket `yaml:"buckets"`
+	// OverridesFile is a YAML map of source index to balance, which takes precedence over the distribution.
+	OverridesFile string `yaml:"overrides_file"`
+
+	overrides   map[uint64]uint64
+	totalWeight uint64
+	meta        map[string]string
+}
+
+// BalanceBucket is a weighted balance of a buckets distribution.
+type BalanceBucket struct {
+	Balance uint64 `yaml:"balance"`
+	Weight  uint64 `yaml:"weight"`
+}
+
+// prepare validates the distribution, loads the overrides file (relative to baseDir) and picks a seed if needed.
+func (d *BalanceDistribution) prepare(baseDir string) error {
+	if d.Step == 0 {
+		d.Step = 1
+	}
+
+	switch d.Type {
+	case BalanceDistributionUniform, BalanceDistributionRamp:
+		if d.Max == 0 || d.Min > d.Max {
+			return fmt.Errorf("invalid %v balance distribution: min must be lower than or equal to max (min: %v, max: %v)", d.Type, d.Min, d.Max)
+		}
+
+		if len(d.Buckets) > 0 {
+			return fmt.Errorf("invalid %v balance distribution: buckets are only allowed for the buckets distribution", d.Type)
+		}
+	case BalanceDistributionBuckets:
+		if len(d.Buckets) == 0 {
+			return errors.New("invalid buckets balance distribution: no buckets")
+		}
+
+		d.totalWeight = 0
+
+		for i, bucket := range d.Buckets {
+			if bucket.Weight == 0 {
+				return fmt.Errorf("invalid buckets balance distribution: bucket %d has no weight", i)
+			}
+
+			d.totalWeight += bucket.Weight
+		}
+	case "":
+		if d.OverridesFile == "" {
+			return errors.New("invalid balance distribution: type or overrides_file required")
+		}
+	default:
+		return fmt.Errorf("invalid balance distribution: unknown type '%v'", d.Type)
+	}
+
+	if d.OverridesFile != "" {
+		overridesData, err := os.ReadFile(resolvePath(baseDir, d.OverridesFile))
+		if err != nil {
+			return fmt.Errorf("failed to read balance overrides file: %w", err)
+		}
+
+		d.overrides = map[uint64]uint64{}
+		if err := yaml.Unmarshal(overridesData, &d.overrides); err != nil {
+			return fmt.Errorf("failed to parse balance overrides file: %w", err)
+		}
+	}
+
+	if d.Seed == nil && (d.Type == BalanceDistributionUniform || d.Type == BalanceDistributionBuckets) {
+		seedBytes := make([]byte, 8)
+		if _, err := rand.Read(seedBytes); err != nil {
+			return fmt.Errorf("failed to generate balance seed: %w", err)
+		}
+
+		seed := binary.LittleEndian.Uint64(seedBytes)
+		d.Seed = &seed
+	}
+
+	d.meta = map[string]string{}
+
+	if d.Type != "" {
+		d.meta[BalanceDistributionMetadataKey] = d.Type
+	} else {
+		d.meta[BalanceDistributionMetadataKey] = "overrides"
+	}
+
+	if d.Seed != nil {
+		d.meta[BalanceSeedMetadataKey] = strconv.FormatUint(*d.Seed, 10)
+	}
+
+	return nil
+}
+
+// random returns a deterministic pseudo random number for the given index.
+func (d *BalanceDistribution) random(index uint64) uint64 {
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data, *d.Seed)
+	binary.LittleEndian.PutUint64(data[8:], index)
+
+	hash := sha256.Sum256(data)
+
+	return binary.LittleEndian.Uint64(hash[:8])
+}
+
+// Balance returns the balance for the validator at the given index of a source with count validators.
+// Returns false if the distribution does not define a balance for the index (overrides only).
+func (d *BalanceDistribution) Balance(index, count uint64) (uint64, bool) {
+	if balance, ok := d.overrides[index]; ok {
+		return balance, true
+	}
+
+	switch d.Type {
+	case BalanceDistributionUniform:
+		steps := (d.Max-d.Min)/d.Step + 1
+		if steps == 0 {
+			// full uint64 range
+			return d.random(index), true
+		}
+
+		return d.Min + (d.random(index)%steps)*d.Step, true
+	case BalanceDistributionRamp:
+		if count <= 1 {
+			return d.Min, true
+		}
+
+		// min + (max - min) * index / (count - 1), without overflowing
+		hi, lo := bits.Mul64(d.Max-d.Min, index)
+		offset, _ := bits.Div64(hi, lo, count-1)
+
+		return d.Min + (offset/d.Step)*d.Step, true
+	case BalanceDistributionBuckets:
+		target := d.random(index) % d.totalWeight
+
+		for _, bucket := range d.Buckets {
+			if target < bucket.Weight {
+				return bucket.Balance, true
+			}
+
+			target -= bucket.Weight
+		}
+	}
+
+	return 0, false
+}
+
+// apply sets the distributed balance and records the distribution (and seed) in the validator metadata.
+func (d *BalanceDistribution) apply(validator *Validator, index, count uint64) {
+	balance, ok := d.Balance(index, count)
+	if !ok {
+		return
+	}
+
+	validator.Balance = &balance
+
+	if validator.Metadata == nil {
+		validator.Metadata = make(map[string]string, 2)
+	}
+
+	for key, value := range d.meta {
+		validator.Metadata[key] = value
+	}
+}
@@ -0,0 +1,284 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	hbls "github.com/herumi/bls-eth-go-binary/bls"
+)
+
+func TestBalanceDistribution_Uniform(t *testing.T) {
+	seed := uint64(1234)
+	distribution := &BalanceDistribution{
+		Type: BalanceDistributionUniform,
+		Seed: &seed,
+		Min:  16000000000,
+		Max:  2048000000000,
+		Step: 1000000000,
+	}
+
+	if err := distribution.prepare("."); err != nil {
+		t.Fatalf("failed to prepare distribution: %v", err)
+	}
+
+	balances := map[uint64]bool{}
+
+	for i := uint64(0); i < 100; i++ {
+		balance, ok := distribution.Balance(i, 100)
+		if !ok || balance < distribution.Min || balance > distribution.Max || balance%distribution.Step != 0 {
+			t.Fatalf("invalid balance %v for index %v", balance, i)
+		}
+
+		if balance2, _ := distribution.Balance(i, 100); balance2 != balance {
+			t.Fatalf("balance for index %v is not deterministic", i)
+		}
+
+		balances[balance] = true
+	}
+
+	if len(balances) < 50 {
+		t.Fatalf("expected heterogeneous balances, got %d distinct values", len(balances))
+	}
+}
+
+func TestBalanceDistribution_Ramp(t *testing.T) {
+	distribution := &BalanceDistribution{
+		Type: BalanceDistributionRamp,
+		Min:  32000000000,
+		Max:  2048000000000,
+	}
+
+	if err := distribution.prepare("."); err != nil {
+		t.Fatalf("failed to prepare distribution: %v", err)
+	}
+
+	if distribution.Seed != nil {
+		t.Fatalf("expected no seed for ramp distribution")
+	}
+
+	if balance, _ := distribution.Balance(0, 5); balance != 32000000000 {
+		t.Fatalf("unexpected first balance: %v", balance)
+	}
+
+	if balance, _ := distribution.Balance(2, 5); balance != 1040000000000 {
+		t.Fatalf("unexpected middle balance: %v", balance)
+	}
+
+	if balance, _ := distribution.Balance(4, 5); balance != 2048000000000 {
+		t.Fatalf("unexpected last balance: %v", balance)
+	}
+}
+
+func TestBalanceDistribution_BucketsAndOverrides(t *testing.T) {
+	overridesFile := filepath.Join(t.TempDir(), "overrides.yaml")
+	if err := os.WriteFile(overridesFile, []byte("3: 1000000000\n7: 2048000000000\n"), 0o600); err != nil {
+		t.Fatalf("failed to write overrides file: %v", err)
+	}
+
+	distribution := &BalanceDistribution{
+		Type: BalanceDistributionBuckets,
+		Buckets: []BalanceBucket{
+			{Balance: 32000000000, Weight: 3},
+			{Balance: 64000000000, Weight: 1},
+		},
+		OverridesFile: filepath.Base(overridesFile),
+	}
+
+	if err := distribution.prepare(filepath.Dir(overridesFile)); err != nil {
+		t.Fatalf("failed to prepare distribution: %v", err)
+	}
+
+	if distribution.Seed == nil {
+		t.Fatalf("expected a random seed to be generated")
+	}
+
+	counts := map[uint64]int{}
+
+	for i := uint64(0); i < 1000; i++ {
+		balance, _ := distribution.Balance(i, 1000)
+		counts[balance]++
+	}
+
+	if counts[1000000000] != 1 || counts[2048000000000] != 1 {
+		t.Fatalf("expected overrides to be applied, got %v", counts)
+	}
+
+	if counts[32000000000] < 650 || counts[64000000000] < 150 || counts[32000000000]+counts[64000000000] != 998 {
+		t.Fatalf("unexpected bucket distribution: %v", counts)
+	}
+}
+
+func TestBalanceDistribution_Invalid(t *testing.T) {
+	tests := []struct {
+		name         string
+		distribution *BalanceDistribution
+		expected     string
+	}{
+		{"unknown type", &BalanceDistribution{Type: "gaussian"}, "unknown type 'gaussian'"},
+		{"empty", &BalanceDistribution{}, "type or overrides_file required"},
+		{"min above max", &BalanceDistribution{Type: BalanceDistributionUniform, Min: 10, Max: 5}, "min must be lower than or equal to max"},
+		{"no buckets", &BalanceDistribution{Type: BalanceDistributionBuckets}, "no buckets"},
+		{"zero weight", &BalanceDistribution{Type: BalanceDistributionBuckets, Buckets: []BalanceBucket{{Balance: 1}}}, "bucket 0 has no weight"},
+		{"missing overrides", &BalanceDistribution{OverridesFile: "missing.yaml"}, "failed to read balance overrides file"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.distribution.prepare(t.TempDir())
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Fatalf("expected error containing '%v', got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestGenerateValidatorsByMnemonic_BalanceDistribution(t *testing.T) {
+	mnemonicsFile := createTestMnemonicsFile(t, `
+- mnemonic: "test test test test test test test test test test test junk"
+  count: 4
+  wd_type: bls
+  balance_distribution:
+    type: uniform
+    seed: 42
+    min: 32000000000
+    max: 64000000000
+`)
+
+	err := hbls.Init(hbls.BLS12_381)
+	if err != nil {
+		t.Fatalf("failed to initialize BLS12-381: %v", err)
+	}
+
+	validators, err := GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators from mnemonics: %v", err)
+	}
+
+	seed := uint64(42)
+	expected := &BalanceDistribution{Type: BalanceDistributionUniform, Seed: &seed, Min: 32000000000, Max: 64000000000}
+
+	if err := expected.prepare("."); err != nil {
+		t.Fatalf("failed to prepare distribution: %v", err)
+	}
+
+	for i, validator := range validators {
+		expectedBalance, _ := expected.Balance(uint64(i), 4)
+		if validator.Balance == nil || *validator.Balance != expectedBalance {
+			t.Fatalf("unexpected balance for validator %d: expected %v", i, expectedBalance)
+		}
+
+		if validator.Metadata[BalanceSeedMetadataKey] != "42" || validator.Metadata[BalanceDistributionMetadataKey] != "uniform" {
+			t.Fatalf("unexpected metadata for validator %d: %v", i, validator.Metadata)
+		}
+	}
+
+	mnemonicsFile = createTestMnemonicsFile(t, `
+- mnemonic: "test test test test test test test test test test test junk"
+  count: 1
+  balance: 32000000000
+  balance_distribution:
+    type: ramp
+    min: 32000000000
+    max: 64000000000
+`)
+
+	_, err = GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err == nil || !strings.Contains(err.Error(), "balance and balance_distribution cannot be combined") {
+		t.Fatalf("expected combined balance error, got %v", err)
+	}
+}
+
+func TestLoadValidatorsFromFile_BalanceDistribution(t *testing.T) {
+	listFile := filepath.Join(t.TempDir(), "validators.yaml")
+	if err := os.WriteFile(listFile, []byte(`
+balance_distribution:
+  type: ramp
+  min: 32000000000
+  max: 64000000000
+validators:
+  - pubkey: "0x933ad9491b62059dd065b560d256d8957a8c402cc6e8d8ee7290ae11e8f7329267a8811c397529dac52ae1342ba58c95"
+    withdrawal_credentials: "0x00f50428677c60f997aadeab24aabf7fceaef491c96a52b463ae91f95611cf71"
+  - pubkey: "0xa1d1ad0714035353258038e964ae9675dc0252ee22cea896825c01458e1807bfad2f9969338798548d9858a571f7425c"
+    withdrawal_credentials: "0x00f50428677c60f997aadeab24aabf7fceaef491c96a52b463ae91f95611cf71"
+    balance: 1000000000
+  - pubkey: "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"
+    withdrawal_credentials: "0x00f50428677c60f997aadeab24aabf7fceaef491c96a52b463ae91f95611cf71"
+`), 0o600); err != nil {
+		t.Fatalf("failed to write validators list: %v", err)
+	}
+
+	validators, err := LoadValidatorsFromFile(listFile)
+	if err != nil {
+		t.Fatalf("failed to load validators: %v", err)
+	}
+
+	if *validators[0].Balance != 32000000000 || *validators[1].Balance != 1000000000 || *validators[2].Balance != 64000000000 {
+		t.Fatalf("unexpected balances: %v, %v, %v", *validators[0].Balance, *validators[1].Balance, *validators[2].Balance)
+	}
+
+	if validators[1].Metadata != nil || validators[2].Metadata[BalanceDistributionMetadataKey] != "ramp" {
+		t.Fatalf("unexpected metadata: %v, %v", validators[1].Metadata, validators[2].Metadata)
+	}
+}
+
+func TestLoadValidatorsFromFile_RandomBalanceSeed(t *testing.T) {
+	list := `
+balance_distribution:
+  type: uniform%v
+  min: 32000000000
+  max: 2048000000000
+validators:
+  - pubkey: "0x933ad9491b62059dd065b560d256d8957a8c402cc6e8d8ee7290ae11e8f7329267a8811c397529dac52ae1342ba58c95"
+    withdrawal_credentials: "0x00f50428677c60f997aadeab24aabf7fceaef491c96a52b463ae91f95611cf71"
+  - pubkey: "0xa1d1ad0714035353258038e964ae9675dc0252ee22cea896825c01458e1807bfad2f9969338798548d9858a571f7425c"
+    withdrawal_credentials: "0x00f50428677c60f997aadeab24aabf7fceaef491c96a52b463ae91f95611cf71"
+`
+
+	listFile := filepath.Join(t.TempDir(), "validators.yaml")
+	if err := os.WriteFile(listFile, []byte(fmt.Sprintf(list, "")), 0o600); err != nil {
+		t.Fatalf("failed to write validators list: %v", err)
+	}
+
+	validators, err := LoadValidatorsFromFile(listFile)
+	if err != nil {
+		t.Fatalf("failed to load validators: %v", err)
+	}
+
+	// the generated seed is written to the validators CSV
+	csvData, err := MarshalValidatorsCSV(validators)
+	if err != nil {
+		t.Fatalf("failed to marshal validators csv: %v", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(csvData)).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse validators csv: %v", err)
+	}
+
+	seedColumn := slices.Index(records[0], "metadata."+BalanceSeedMetadataKey)
+	if seedColumn < 0 || records[1][seedColumn] == "" || records[1][seedColumn] != records[2][seedColumn] {
+		t.Fatalf("expected balance seed column in validators csv, got:\n%s", csvData)
+	}
+
+	// the seed from the csv reproduces the balances
+	if err := os.WriteFile(listFile, []byte(fmt.Sprintf(list, "\n  seed: "+records[1][seedColumn])), 0o600); err != nil {
+		t.Fatalf("failed to write validators list: %v", err)
+	}
+
+	reproduced, err := LoadValidatorsFromFile(listFile)
+	if err != nil {
+		t.Fatalf("failed to load validators: %v", err)
+	}
+
+	for i := range validators {
+		if *reproduced[i].Balance != *validators[i].Balance {
+			t.Fatalf("validator %d: balance %v not reproduced (%v)", i, *validators[i].Balance, *reproduced[i].Balance)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	dynssz "github.com/pk910/dynamic-ssz"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+)
+
+// StateValidatorsFilter selects the validators imported from an existing beacon state.
+type StateValidatorsFilter struct {
+	// Statuses is a list of validator statuses (e.g. active_ongoing) or status groups (pending, active, exited, withdrawal).
+	// All validators are imported if empty.
+	Statuses []string
+	// StartIndex is the first validator index to import.
+	StartIndex uint64
+	// EndIndex is the validator index to stop importing at (exclusive). 0 means no limit.
+	EndIndex uint64
+	// UseEffectiveBalance imports the effective balance instead of the actual balance.
+	UseEffectiveBalance bool
+}
+
+// ParseIndexRange parses an index range in the format "start:end" (end exclusive, both optional).
+func (f *StateValidatorsFilter) ParseIndexRange(indexRange string) error {
+	parts := strings.Split(indexRange, ":")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid index range '%v', expected 'start:end'", indexRange)
+	}
+
+	if parts[0] != "" {
+		start, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid range start: %w", err)
+		}
+
+		f.StartIndex = start
+	}
+
+	if parts[1] != "" {
+		end, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid range end: %w", err)
+		}
+
+		if end <= f.StartIndex {
+			return fmt.Errorf("invalid index range '%v', end must be greater than start", indexRange)
+		}
+
+		f.EndIndex = end
+	}
+
+	return nil
+}
+
+// stateValidatorsStatusGroups are the status groups accepted by the status filter besides the validator statuses.
+var stateValidatorsStatusGroups = []string{"pending", "active", "exited", "withdrawal"}
+
+// checkStatuses checks the statuses of the filter against the known validator statuses and status groups,
+// so a misspelled status fails instead of silently matching no validators.
+func (f *StateValidatorsFilter) checkStatuses() error {
+	for _, filterStatus := range f.Statuses {
+		if slices.Contains(stateValidatorsStatusGroups, filterStatus) {
+			continue
+		}
+
+		known := false
+
+		for status := apiv1.ValidatorStatePendingInitialized; status <= apiv1.ValidatorStateWithdrawalDone; status++ {
+			if status.String() == filterStatus {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return fmt.Errorf("unknown validator status '%v', expected a status (e.g. active_ongoing) or status group (%v)",
+				filterStatus, strings.Join(stateValidatorsStatusGroups, ", "))
+		}
+	}
+
+	return nil
+}
+
+func (f *StateValidatorsFilter) matches(index phase0.ValidatorIndex, status apiv1.ValidatorState) bool {
+	if uint64(index) < f.StartIndex || (f.EndIndex > 0 && uint64(index) >= f.EndIndex) {
+		return false
+	}
+
+	if len(f.Statuses) == 0 {
+		return true
+	}
+
+	statusStr := status.String()
+
+	for _, filterStatus := range f.Statuses {
+		if filterStatus == statusStr || strings.HasPrefix(statusStr, filterStatus+"_") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadValidatorsFromBeaconState imports validators from a beacon state in SSZ format or from
+// the JSON response of the /eth/v1/beacon/states/{state_id}/validators beacon API endpoint.
+// The path can also be a http(s) URL.
+func LoadValidatorsFromBeaconState(statePath string, clConfig *config.Config, filter *StateValidatorsFilter) ([]*Validator, error) {
+	if filter == nil {
+		filter = &StateValidatorsFilter{}
+	}
+
+	if err := filter.checkStatuses(); err != nil {
+		return nil, err
+	}
+
+	data, err := readStateFile(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var stateValidators []*apiv1.Validator
+
+	trimmedData := bytes.TrimSpace(data)
+	if len(trimmedData) > 0 && (trimmedData[0] == '{' || trimmedData[0] == '[') {
+		stateValidators, err = parseValidatorsJSON(trimmedData)
+	} else {
+		stateValidators, err = parseValidatorsSSZ(data, clConfig)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]*Validator, 0, len(stateValidators))
+	pubkeyMap := map[phase0.BLSPubKey]phase0.ValidatorIndex{}
+
+	for _, stateValidator := range stateValidators {
+		if stateValidator.Validator == nil || !filter.matches(stateValidator.Index, stateValidator.Status) {
+			continue
+		}
+
+		if otherIndex, exists := pubkeyMap[stateValidator.Validator.PublicKey]; exists {
+			return nil, fmt.Errorf("duplicate pubkey for validator %v and %v", otherIndex, stateValidator.Index)
+		}
+
+		pubkeyMap[stateValidator.Validator.PublicKey] = stateValidator.Index
+
+		if err := checkWithdrawalCredentials(stateValidator.Validator.WithdrawalCredentials); err != nil {
+			return nil, fmt.Errorf("invalid withdrawal credentials (%v) for validator %v", err, stateValidator.Index)
+		}
+
+		balance := uint64(stateValidator.Balance)
+		if filter.UseEffectiveBalance {
+			balance = uint64(stateValidator.Validator.EffectiveBalance)
+		}
+
+		withdrawalCred := make([]byte, 32)
+		copy(withdrawalCred, stateValidator.Validator.WithdrawalCredentials)
+
+		validators = append(validators, &Validator{
+			PublicKey:             stateValidator.Validator.PublicKey,
+			WithdrawalCredentials: withdrawalCred,
+			Balance:               &balance,
+		})
+	}
+
+	return validators, nil
+}
+
+// LoadBeaconState loads a SSZ encoded beacon state from a file or http(s) URL.
+func LoadBeaconState(statePath string, clConfig *config.Config) (*spec.VersionedBeaconState, error) {
+	data, err := readStateFile(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < stateForkVersionOffset+4 {
+		return nil, fmt.Errorf("invalid beacon state (too short)")
+	}
+
+	return unmarshalBeaconState(data, clConfig)
+}
+
+// stateHTTPClient downloads beacon states, which can be several hundred megabytes for public networks.
+var stateHTTPClient = &http.Client{Timeout: 5 * time.Minute}
+
+func readStateFile(statePath string) ([]byte, error) {
+	if !strings.HasPrefix(statePath, "http://") && !strings.HasPrefix(statePath, "https://") {
+		return os.ReadFile(statePath)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, statePath, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if strings.Contains(statePath, "/eth/v2/debug/beacon/states/") {
+		req.Header.Set("Accept", "application/octet-stream")
+	} else {
+		req.Header.Set("Accept", "application/json")
+	}
+
+	resp, err := stateHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state from URL: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get state from URL: status %v", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state from URL: %w", err)
+	}
+
+	return data, nil
+}
+
+func parseValidatorsJSON(data []byte) ([]*apiv1.Validator, error) {
+	var stateValidators []*apiv1.Validator
+
+	if data[0] == '{' {
+		var response struct {
+			Data []*apiv1.Validator `json:"data"`
+		}
+
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse validators response: %w", err)
+		}
+
+		stateValidators = response.Data
+	} else if err := json.Unmarshal(data, &stateValidators); err != nil {
+		return nil, fmt.Errorf("failed to parse validators list: %w", err)
+	}
+
+	return stateValidators, nil
+}
+
+// stateForkVersionOffset is the offset of fork.current_version in a SSZ encoded beacon state
+// (genesis_time + genesis_validators_root + slot + fork.previous_version).
+const stateForkVersionOffset = 8 + 32 + 8 + 4
+
+var stateForkVersionFields = []struct {
+	version spec.DataVersion
+	field   string
+}{
+	{spec.DataVersionElectra, "ELECTRA_FORK_VERSION"},
+	{spec.DataVersionDeneb, "DENEB_FORK_VERSION"},
+	{spec.DataVersionCapella, "CAPELLA_FORK_VERSION"},
+	{spec.DataVersionBellatrix, "BELLATRIX_FORK_VERSION"},
+	{spec.DataVersionAltair, "ALTAIR_FORK_VERSION"},
+	{spec.DataVersionPhase0, "GENESIS_FORK_VERSION"},
+}
+
+func parseValidatorsSSZ(data []byte, clConfig *config.Config) ([]*apiv1.Validator, error) {
+	if len(data) < stateForkVersionOffset+4 {
+		return nil, fmt.Errorf("invalid beacon state (too short)")
+	}
+
+	state, err := unmarshalBeaconState(data, clConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := state.Slot()
+	if err != nil {
+		return nil, err
+	}
+
+	stateValidators, err := state.Validators()
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := state.ValidatorBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(balances) != len(stateValidators) {
+		return nil, fmt.Errorf("invalid beacon state (%v validators, %v balances)", len(stateValidators), len(balances))
+	}
+
+	slotsPerEpoch := clConfig.GetUintOrDefault("SLOTS_PER_EPOCH")
+	farFutureEpoch := phase0.Epoch(clConfig.GetUintDefault("FAR_FUTURE_EPOCH", 18446744073709551615))
+	currentEpoch := phase0.Epoch(uint64(slot) / slotsPerEpoch)
+
+	result := make([]*apiv1.Validator, len(stateValidators))
+
+	for i, validator := range stateValidators {
+		result[i] = &apiv1.Validator{
+			Index:     phase0.ValidatorIndex(i),
+			Balance:   balances[i],
+			Status:    apiv1.ValidatorToState(validator, &balances[i], currentEpoch, farFutureEpoch),
+			Validator: validator,
+		}
+	}
+
+	return result, nil
+}
+
+// unmarshalBeaconState decodes a SSZ encoded beacon state.
+// The fork is detected via the fork version in the state, all forks are tried if the version is unknown.
+func unmarshalBeaconState(data []byte, clConfig *config.Config) (*spec.VersionedBeaconState, error) {
+	dynSsz := dynssz.NewDynSsz(clConfig.GetSpecs())
+	stateForkVersion := data[stateForkVersionOffset : stateForkVersionOffset+4]
+	candidates := []spec.DataVersion{}
+
+	for _, forkVersionField := range stateForkVersionFields {
+		if forkVersion, ok := clConfig.GetBytes(forkVersionField.field); ok && bytes.Equal(forkVersion, stateForkVersion) {
+			candidates = append(candidates, forkVersionField.version)
+		}
+	}
+
+	if len(candidates) == 0 {
+		for _, forkVersionField := range stateForkVersionFields {
+			candidates = append(candidates, forkVersionField.version)
+		}
+	}
+
+	var lastErr error
+
+	for _, version := range candidates {
+		state := &spec.VersionedBeaconState{Version: version}
+
+		var target any
+
+		switch version {
+		case spec.DataVersionPhase0:
+			state.Phase0 = &phase0.BeaconState{}
+			target = state.Phase0
+		case spec.DataVersionAltair:
+			state.Altair = &altair.BeaconState{}
+			target = state.Altair
+		case spec.DataVersionBellatrix:
+			state.Bellatrix = &bellatrix.BeaconState{}
+			target = state.Bellatrix
+		case spec.DataVersionCapella:
+			state.Capella = &capella.BeaconState{}
+			target = state.Capella
+		case spec.DataVersionDeneb:
+			state.Deneb = &deneb.BeaconState{}
+			target = state.Deneb
+		case spec.DataVersionElectra:
+			state.Electra = &electra.BeaconState{}
+			target = state.Electra
+		default:
+			continue
+		}
+
+		if err := dynSsz.UnmarshalSSZ(target, data); err != nil {
+			lastErr = fmt.Errorf("failed to decode %v beacon state: %w", version, err)
+			continue
+		}
+
+		return state, nil
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,195 @@
+package validators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	dynssz "github.com/pk910/dynamic-ssz"
+
+	"github.com/ethpandaops/eth-beacon-genesis/config"
+)
+
+func createTestBeaconStateConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(`
+PRESET_BASE: minimal
+GENESIS_FORK_VERSION: 0x00000001
+ALTAIR_FORK_VERSION: 0x01000001
+`), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	clConfig, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	return clConfig
+}
+
+func createTestStateValidator(seed byte, activation, exit phase0.Epoch, effectiveBalance phase0.Gwei) *phase0.Validator {
+	pubkey := phase0.BLSPubKey{}
+	pubkey[0] = 0x80
+	pubkey[47] = seed
+
+	withdrawalCred := make([]byte, 32)
+	withdrawalCred[0] = 0x01
+	withdrawalCred[31] = seed
+
+	return &phase0.Validator{
+		PublicKey:                  pubkey,
+		WithdrawalCredentials:      withdrawalCred,
+		EffectiveBalance:           effectiveBalance,
+		ActivationEligibilityEpoch: 0,
+		ActivationEpoch:            activation,
+		ExitEpoch:                  exit,
+		WithdrawableEpoch:          phase0.Epoch(18446744073709551615),
+	}
+}
+
+func TestLoadValidatorsFromBeaconState_SSZ(t *testing.T) {
+	clConfig := createTestBeaconStateConfig(t)
+	farFuture := phase0.Epoch(18446744073709551615)
+
+	state := &altair.BeaconState{
+		Slot: 8 * 10, // epoch 10 (minimal preset)
+		Fork: &phase0.Fork{
+			PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x01},
+			CurrentVersion:  phase0.Version{0x01, 0x00, 0x00, 0x01},
+		},
+		LatestBlockHeader: &phase0.BeaconBlockHeader{},
+		BlockRoots:        make([]phase0.Root, 64),
+		StateRoots:        make([]phase0.Root, 64),
+		ETH1Data:          &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+		Validators: []*phase0.Validator{
+			createTestStateValidator(1, 0, farFuture, 32000000000),
+			createTestStateValidator(2, 0, 5, 32000000000),
+			createTestStateValidator(3, 20, farFuture, 32000000000),
+			createTestStateValidator(4, 0, farFuture, 31000000000),
+		},
+		Balances:                    []phase0.Gwei{32100000000, 0, 32000000000, 31900000000},
+		RANDAOMixes:                 make([]phase0.Root, 64),
+		Slashings:                   make([]phase0.Gwei, 64),
+		PreviousEpochParticipation:  make([]altair.ParticipationFlags, 4),
+		CurrentEpochParticipation:   make([]altair.ParticipationFlags, 4),
+		JustificationBits:           make([]byte, 1),
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+		FinalizedCheckpoint:         &phase0.Checkpoint{},
+		InactivityScores:            make([]uint64, 4),
+		CurrentSyncCommittee:        &altair.SyncCommittee{Pubkeys: make([]phase0.BLSPubKey, 32)},
+		NextSyncCommittee:           &altair.SyncCommittee{Pubkeys: make([]phase0.BLSPubKey, 32)},
+	}
+
+	sszData, err := dynssz.NewDynSsz(clConfig.GetSpecs()).MarshalSSZ(state)
+	if err != nil {
+		t.Fatalf("failed to marshal state: %v", err)
+	}
+
+	statePath := filepath.Join(t.TempDir(), "state.ssz")
+	if err := os.WriteFile(statePath, sszData, 0o600); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+
+	validators, err := LoadValidatorsFromBeaconState(statePath, clConfig, nil)
+	if err != nil {
+		t.Fatalf("failed to load validators from state: %v", err)
+	}
+
+	if len(validators) != 4 {
+		t.Fatalf("expected 4 validators, got %d", len(validators))
+	}
+
+	if *validators[0].Balance != 32100000000 || validators[0].WithdrawalCredentials[31] != 1 {
+		t.Fatalf("unexpected validator 0: balance %v, credentials 0x%x", *validators[0].Balance, validators[0].WithdrawalCredentials)
+	}
+
+	validators, err = LoadValidatorsFromBeaconState(statePath, clConfig, &StateValidatorsFilter{
+		Statuses:            []string{"active"},
+		UseEffectiveBalance: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to load validators from state: %v", err)
+	}
+
+	if len(validators) != 2 {
+		t.Fatalf("expected 2 active validators, got %d", len(validators))
+	}
+
+	if validators[1].PublicKey[47] != 4 || *validators[1].Balance != 31000000000 {
+		t.Fatalf("unexpected validator 1: pubkey %v, balance %v", validators[1].PublicKey.String(), *validators[1].Balance)
+	}
+
+	filter := &StateValidatorsFilter{}
+	if err := filter.ParseIndexRange("1:3"); err != nil {
+		t.Fatalf("failed to parse index range: %v", err)
+	}
+
+	validators, err = LoadValidatorsFromBeaconState(statePath, clConfig, filter)
+	if err != nil {
+		t.Fatalf("failed to load validators from state: %v", err)
+	}
+
+	if len(validators) != 2 || validators[0].PublicKey[47] != 2 || validators[1].PublicKey[47] != 3 {
+		t.Fatalf("unexpected validators for index range 1:3")
+	}
+
+	_, err = LoadValidatorsFromBeaconState(statePath, clConfig, &StateValidatorsFilter{Statuses: []string{"active_ongiong"}})
+	if err == nil || !strings.Contains(err.Error(), "unknown validator status 'active_ongiong'") {
+		t.Fatalf("expected unknown validator status error, got %v", err)
+	}
+}
+
+func TestLoadValidatorsFromBeaconState_JSON(t *testing.T) {
+	clConfig := createTestBeaconStateConfig(t)
+	statePath := filepath.Join(t.TempDir(), "validators.json")
+
+	if err := os.WriteFile(statePath, []byte(`{"execution_optimistic":false,"finalized":true,"data":[
+{"index":"0","balance":"32000000000","status":"active_ongoing","validator":{"pubkey":"0x933ad9491b62059dd065b560d256d8957a8c402cc6e8d8ee7290ae11e8f7329267a8811c397529dac52ae1342ba58c95","withdrawal_credentials":"0x0100000000000000000000001234567890abcdef1234567890abcdef12345678","effective_balance":"32000000000","slashed":false,"activation_eligibility_epoch":"0","activation_epoch":"0","exit_epoch":"18446744073709551615","withdrawable_epoch":"18446744073709551615"}},
+{"index":"1","balance":"0","status":"withdrawal_done","validator":{"pubkey":"0xa1d1ad0714035353258038e964ae9675dc0252ee22cea896825c01458e1807bfad2f9969338798548d9858a571f7425c","withdrawal_credentials":"0x00f50428677c60f997aadeab24aabf7fceaef491c96a52b463ae91f95611cf71","effective_balance":"0","slashed":false,"activation_eligibility_epoch":"0","activation_epoch":"0","exit_epoch":"100","withdrawable_epoch":"356"}}
+]}`), 0o600); err != nil {
+		t.Fatalf("failed to write validators: %v", err)
+	}
+
+	validators, err := LoadValidatorsFromBeaconState(statePath, clConfig, &StateValidatorsFilter{
+		Statuses: []string{"active_ongoing", "pending"},
+	})
+	if err != nil {
+		t.Fatalf("failed to load validators: %v", err)
+	}
+
+	if len(validators) != 1 {
+		t.Fatalf("expected 1 validator, got %d", len(validators))
+	}
+
+	if validators[0].PublicKey.String() != "0x933ad9491b62059dd065b560d256d8957a8c402cc6e8d8ee7290ae11e8f7329267a8811c397529dac52ae1342ba58c95" {
+		t.Fatalf("unexpected pubkey: %s", validators[0].PublicKey.String())
+	}
+
+	if *validators[0].Balance != 32000000000 {
+		t.Fatalf("unexpected balance: %d", *validators[0].Balance)
+	}
+}
+
+func TestStateValidatorsFilter_ParseIndexRange(t *testing.T) {
+	filter := &StateValidatorsFilter{}
+	if err := filter.ParseIndexRange("100:"); err != nil || filter.StartIndex != 100 || filter.EndIndex != 0 {
+		t.Fatalf("unexpected result for '100:': %v %v %v", err, filter.StartIndex, filter.EndIndex)
+	}
+
+	filter = &StateValidatorsFilter{}
+	if err := filter.ParseIndexRange(":50"); err != nil || filter.StartIndex != 0 || filter.EndIndex != 50 {
+		t.Fatalf("unexpected result for ':50': %v %v %v", err, filter.StartIndex, filter.EndIndex)
+	}
+
+	filter = &StateValidatorsFilter{}
+	if err := filter.ParseIndexRange("50:10"); err == nil || !strings.Contains(err.Error(), "end must be greater than start") {
+		t.Fatalf("expected error for '50:10', got %v", err)
+	}
+}
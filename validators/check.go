@@ -0,0 +1,108 @@
+package validators
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"slices"
+
+	blsu "github.com/protolambda/bls12-381-util"
+	"golang.org/x/sync/errgroup"
+)
+
+// SourceValidators is the list of validators loaded from a single validator source.
+type SourceValidators struct {
+	// Name identifies the source in error messages (e.g. "mnemonics" or "deposit-data deposits.json").
+	Name string
+	// Generated is set for sources that derive the pubkeys from secret keys (mnemonics, interop, synthetic).
+	// The pubkeys of generated sources are valid by construction and are not checked again.
+	Generated  bool
+	Validators []*Validator
+}
+
+// validatorPosition references a validator by source and position within the source.
+type validatorPosition struct {
+	source *SourceValidators
+	index  int
+}
+
+func (p validatorPosition) String() string {
+	return fmt.Sprintf("%v validator %v", p.source.Name, p.index)
+}
+
+// ValidateSources checks that the pubkeys of all validators are valid compressed G1 points in the
+// correct subgroup and that each pubkey is unique across all sources.
+func ValidateSources(sources []*SourceValidators) error {
+	if err := validatePubkeys(sources); err != nil {
+		return err
+	}
+
+	return checkDuplicatePubkeys(sources)
+}
+
+// pubkeyCheckChunkSize is the number of pubkeys checked per worker task.
+const pubkeyCheckChunkSize = 1024
+
+func validatePubkeys(sources []*SourceValidators) error {
+	var g errgroup.Group
+
+	g.SetLimit(runtime.NumCPU())
+
+	for _, source := range sources {
+		if source.Generated {
+			continue
+		}
+
+		for chunkStart := 0; chunkStart < len(source.Validators); chunkStart += pubkeyCheckChunkSize {
+			chunkEnd := min(chunkStart+pubkeyCheckChunkSize, len(source.Validators))
+
+			g.Go(func() error {
+				var pubkey blsu.Pubkey
+
+				for i := chunkStart; i < chunkEnd; i++ {
+					validator := source.Validators[i]
+					if err := pubkey.Deserialize((*[48]byte)(validator.PublicKey[:])); err != nil {
+						return fmt.Errorf("invalid pubkey %v (%v): %w", validator.PublicKey.String(), validatorPosition{source, i}, err)
+					}
+				}
+
+				return nil
+			})
+		}
+	}
+
+	return g.Wait()
+}
+
+// checkDuplicatePubkeys detects duplicate pubkeys by sorting the validator positions by pubkey,
+// which needs much less memory than a map for millions of validators.
+func checkDuplicatePubkeys(sources []*SourceValidators) error {
+	count := 0
+	for _, source := range sources {
+		count += len(source.Validators)
+	}
+
+	positions := make([]validatorPosition, 0, count)
+
+	for _, source := range sources {
+		for i := range source.Validators {
+			positions = append(positions, validatorPosition{source, i})
+		}
+	}
+
+	pubkey := func(p validatorPosition) []byte {
+		return p.source.Validators[p.index].PublicKey[:]
+	}
+
+	slices.SortStableFunc(positions, func(a, b validatorPosition) int {
+		return bytes.Compare(pubkey(a), pubkey(b))
+	})
+
+	for i := 1; i < len(positions); i++ {
+		if bytes.Equal(pubkey(positions[i-1]), pubkey(positions[i])) {
+			return fmt.Errorf("duplicate pubkey 0x%x (%v and %v)", pubkey(positions[i]), positions[i-1], positions[i])
+		}
+	}
+
+	return nil
+}
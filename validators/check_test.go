@@ -0,0 +1,76 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSources(t *testing.T) {
+	interopValidators, err := GenerateInteropValidators(0, 4)
+	if err != nil {
+		t.Fatalf("failed to generate interop validators: %v", err)
+	}
+
+	sources := []*SourceValidators{
+		{Name: "interop", Generated: true, Validators: interopValidators[:2]},
+		{Name: "keystores", Validators: interopValidators[2:]},
+	}
+
+	if err := ValidateSources(sources); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateSourcesDuplicate(t *testing.T) {
+	interopValidators, err := GenerateInteropValidators(0, 3)
+	if err != nil {
+		t.Fatalf("failed to generate interop validators: %v", err)
+	}
+
+	duplicate := *interopValidators[1]
+
+	sources := []*SourceValidators{
+		{Name: "interop", Generated: true, Validators: interopValidators[:2]},
+		{Name: "deposit-data deposits.json", Validators: []*Validator{interopValidators[2], &duplicate}},
+	}
+
+	err = ValidateSources(sources)
+	if err == nil {
+		t.Fatalf("expected duplicate pubkey error")
+	}
+
+	if !strings.Contains(err.Error(), "duplicate pubkey") ||
+		!strings.Contains(err.Error(), "interop validator 1 and deposit-data deposits.json validator 1") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateSourcesInvalidPubkey(t *testing.T) {
+	interopValidators, err := GenerateInteropValidators(0, 2)
+	if err != nil {
+		t.Fatalf("failed to generate interop validators: %v", err)
+	}
+
+	invalid := *interopValidators[1]
+	invalid.PublicKey[47] ^= 0x01
+
+	sources := []*SourceValidators{
+		{Name: "additional-validators", Validators: []*Validator{interopValidators[0], &invalid}},
+	}
+
+	err = ValidateSources(sources)
+	if err == nil {
+		t.Fatalf("expected invalid pubkey error")
+	}
+
+	if !strings.Contains(err.Error(), "invalid pubkey") || !strings.Contains(err.Error(), "additional-validators validator 1") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// generated sources are valid by construction and not checked again
+	sources[0].Generated = true
+
+	if err := ValidateSources(sources); err != nil {
+		t.Fatalf("unexpected error for generated source: %v", err)
+	}
+}
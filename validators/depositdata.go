@@ -0,0 +1,214 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	blsu "github.com/protolambda/bls12-381-util"
+)
+
+// DomainDeposit is the signature domain type used for deposits.
+var DomainDeposit = phase0.DomainType{0x03, 0x00, 0x00, 0x00}
+
+// DepositDataEntry is a single entry of a deposit_data-*.json file as written by the staking-deposit-cli.
+type DepositDataEntry struct {
+	PubKey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"network_name,omitempty"`
+	DepositCliVersion     string `json:"deposit_cli_version,omitempty"`
+}
+
+// LoadValidatorsFromDepositData loads validators from a deposit_data-*.json file.
+// The proof of possession of every entry is verified against the given genesis fork version.
+func LoadValidatorsFromDepositData(depositDataPath string, genesisForkVersion phase0.Version) ([]*Validator, error) {
+	data, err := os.ReadFile(depositDataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*DepositDataEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse deposit data: %w", err)
+	}
+
+	domain, err := ComputeDepositDomain(genesisForkVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute deposit domain: %w", err)
+	}
+
+	validators := make([]*Validator, 0, len(entries))
+	pubkeyMap := map[string]int{}
+
+	for i, entry := range entries {
+		depositData, err := entry.toDepositData()
+		if err != nil {
+			return nil, fmt.Errorf("invalid deposit %v: %w", i, err)
+		}
+
+		if pubkeyMap[string(depositData.PublicKey[:])] != 0 {
+			return nil, fmt.Errorf("duplicate pubkey in deposit %v and %v", pubkeyMap[string(depositData.PublicKey[:])]-1, i)
+		}
+
+		pubkeyMap[string(depositData.PublicKey[:])] = i + 1
+
+		if entry.ForkVersion != "" {
+			forkVersion, err := hex.DecodeString(strings.ReplaceAll(entry.ForkVersion, "0x", ""))
+			if err != nil {
+				return nil, fmt.Errorf("invalid fork_version in deposit %v: %w", i, err)
+			}
+
+			if !bytes.Equal(forkVersion, genesisForkVersion[:]) {
+				return nil, fmt.Errorf("fork_version mismatch in deposit %v: got 0x%x, expected 0x%x", i, forkVersion, genesisForkVersion)
+			}
+		}
+
+		if err := verifyDepositData(depositData, domain); err != nil {
+			return nil, fmt.Errorf("invalid deposit %v: %w", i, err)
+		}
+
+		if entry.DepositDataRoot != "" {
+			expectedRoot, err := hex.DecodeString(strings.ReplaceAll(entry.DepositDataRoot, "0x", ""))
+			if err != nil {
+				return nil, fmt.Errorf("invalid deposit_data_root in deposit %v: %w", i, err)
+			}
+
+			depositDataRoot, err := depositData.HashTreeRoot()
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute deposit_data_root for deposit %v: %w", i, err)
+			}
+
+			if !bytes.Equal(expectedRoot, depositDataRoot[:]) {
+				return nil, fmt.Errorf("deposit_data_root mismatch in deposit %v: got 0x%x, computed 0x%x", i, expectedRoot, depositDataRoot)
+			}
+		}
+
+		balance := uint64(depositData.Amount)
+		validators = append(validators, &Validator{
+			PublicKey:             depositData.PublicKey,
+			WithdrawalCredentials: depositData.WithdrawalCredentials,
+			Balance:               &balance,
+		})
+	}
+
+	return validators, nil
+}
+
+// ComputeDepositDomain computes the signature domain for deposits.
+// Deposits are valid across forks, so the genesis validators root is always zero.
+func ComputeDepositDomain(genesisForkVersion phase0.Version) (phase0.Domain, error) {
+	forkData := &phase0.ForkData{
+		CurrentVersion:        genesisForkVersion,
+		GenesisValidatorsRoot: phase0.Root{},
+	}
+
+	forkDataRoot, err := forkData.HashTreeRoot()
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+
+	var domain phase0.Domain
+
+	copy(domain[:4], DomainDeposit[:])
+	copy(domain[4:], forkDataRoot[:28])
+
+	return domain, nil
+}
+
+func (entry *DepositDataEntry) toDepositData() (*phase0.DepositData, error) {
+	pubKey, err := hex.DecodeString(strings.ReplaceAll(entry.PubKey, "0x", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pubkey: %w", err)
+	}
+
+	if len(pubKey) != 48 {
+		return nil, fmt.Errorf("invalid pubkey (invalid length)")
+	}
+
+	withdrawalCred, err := hex.DecodeString(strings.ReplaceAll(entry.WithdrawalCredentials, "0x", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid withdrawal credentials: %w", err)
+	}
+
+	if err := checkWithdrawalCredentials(withdrawalCred); err != nil {
+		return nil, fmt.Errorf("invalid withdrawal credentials (%v)", err)
+	}
+
+	signature, err := hex.DecodeString(strings.ReplaceAll(entry.Signature, "0x", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	if len(signature) != 96 {
+		return nil, fmt.Errorf("invalid signature (invalid length)")
+	}
+
+	if entry.Amount == 0 {
+		return nil, fmt.Errorf("invalid amount (zero)")
+	}
+
+	return &phase0.DepositData{
+		PublicKey:             phase0.BLSPubKey(pubKey),
+		WithdrawalCredentials: withdrawalCred,
+		Amount:                phase0.Gwei(entry.Amount),
+		Signature:             phase0.BLSSignature(signature),
+	}, nil
+}
+
+// depositSigningRoot computes the signing root of the deposit message of a deposit.
+func depositSigningRoot(depositData *phase0.DepositData, domain phase0.Domain) (phase0.Root, error) {
+	depositMessage := &phase0.DepositMessage{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+	}
+
+	messageRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("failed to compute deposit message root: %w", err)
+	}
+
+	signingData := &phase0.SigningData{
+		ObjectRoot: messageRoot,
+		Domain:     domain,
+	}
+
+	signingRoot, err := signingData.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("failed to compute signing root: %w", err)
+	}
+
+	return signingRoot, nil
+}
+
+func verifyDepositData(depositData *phase0.DepositData, domain phase0.Domain) error {
+	signingRoot, err := depositSigningRoot(depositData, domain)
+	if err != nil {
+		return err
+	}
+
+	var pubKey blsu.Pubkey
+	if err := pubKey.Deserialize((*[48]byte)(depositData.PublicKey[:])); err != nil {
+		return fmt.Errorf("invalid pubkey: %w", err)
+	}
+
+	var signature blsu.Signature
+	if err := signature.Deserialize((*[96]byte)(depositData.Signature[:])); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	if !blsu.Verify(&pubKey, signingRoot[:], &signature) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	blsu "github.com/protolambda/bls12-381-util"
+)
+
+func createTestDepositDataEntry(t *testing.T, seed byte, withdrawalCred []byte, amount uint64, forkVersion phase0.Version) *DepositDataEntry {
+	t.Helper()
+
+	var skBytes [32]byte
+	skBytes[31] = seed
+
+	var sk blsu.SecretKey
+	if err := sk.Deserialize(&skBytes); err != nil {
+		t.Fatalf("failed to deserialize secret key: %v", err)
+	}
+
+	pk, err := blsu.SkToPk(&sk)
+	if err != nil {
+		t.Fatalf("failed to derive pubkey: %v", err)
+	}
+
+	pubKey := pk.Serialize()
+	depositMessage := &phase0.DepositMessage{
+		PublicKey:             phase0.BLSPubKey(pubKey),
+		WithdrawalCredentials: withdrawalCred,
+		Amount:                phase0.Gwei(amount),
+	}
+
+	messageRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("failed to compute deposit message root: %v", err)
+	}
+
+	domain, err := ComputeDepositDomain(forkVersion)
+	if err != nil {
+		t.Fatalf("failed to compute deposit domain: %v", err)
+	}
+
+	signingRoot, err := (&phase0.SigningData{ObjectRoot: messageRoot, Domain: domain}).HashTreeRoot()
+	if err != nil {
+		t.Fatalf("failed to compute signing root: %v", err)
+	}
+
+	signature := blsu.Sign(&sk, signingRoot[:]).Serialize()
+	depositData := &phase0.DepositData{
+		PublicKey:             phase0.BLSPubKey(pubKey),
+		WithdrawalCredentials: withdrawalCred,
+		Amount:                phase0.Gwei(amount),
+		Signature:             phase0.BLSSignature(signature),
+	}
+
+	depositDataRoot, err := depositData.HashTreeRoot()
+	if err != nil {
+		t.Fatalf("failed to compute deposit data root: %v", err)
+	}
+
+	return &DepositDataEntry{
+		PubKey:                fmt.Sprintf("%x", pubKey),
+		WithdrawalCredentials: fmt.Sprintf("%x", withdrawalCred),
+		Amount:                amount,
+		Signature:             fmt.Sprintf("%x", signature),
+		DepositMessageRoot:    fmt.Sprintf("%x", messageRoot),
+		DepositDataRoot:       fmt.Sprintf("%x", depositDataRoot),
+		ForkVersion:           fmt.Sprintf("%x", forkVersion),
+		NetworkName:           "devnet",
+		DepositCliVersion:     "2.7.0",
+	}
+}
+
+func createTestDepositDataFile(t *testing.T, entries []*DepositDataEntry) string {
+	t.Helper()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("failed to marshal deposit data: %v", err)
+	}
+
+	depositDataFile := filepath.Join(t.TempDir(), "deposit_data-1700000000.json")
+	if err := os.WriteFile(depositDataFile, data, 0o600); err != nil {
+		t.Fatalf("failed to write deposit data: %v", err)
+	}
+
+	return depositDataFile
+}
+
+func TestLoadValidatorsFromDepositData_Valid(t *testing.T) {
+	forkVersion := phase0.Version{0x10, 0x00, 0x00, 0x38}
+	wdCred0 := append([]byte{0x00}, make([]byte, 31)...)
+	wdCred1 := append([]byte{0x01}, make([]byte, 31)...)
+	wdCred1[31] = 0xaa
+
+	depositDataFile := createTestDepositDataFile(t, []*DepositDataEntry{
+		createTestDepositDataEntry(t, 1, wdCred0, 32000000000, forkVersion),
+		createTestDepositDataEntry(t, 2, wdCred1, 64000000000, forkVersion),
+	})
+
+	validators, err := LoadValidatorsFromDepositData(depositDataFile, forkVersion)
+	if err != nil {
+		t.Fatalf("failed to load validators from deposit data: %v", err)
+	}
+
+	if len(validators) != 2 {
+		t.Fatalf("expected 2 validators, got %d", len(validators))
+	}
+
+	if validators[0].Balance == nil || *validators[0].Balance != 32000000000 {
+		t.Fatalf("expected validator 0 to have balance 32000000000, got %v", validators[0].Balance)
+	}
+
+	if validators[1].Balance == nil || *validators[1].Balance != 64000000000 {
+		t.Fatalf("expected validator 1 to have balance 64000000000, got %v", validators[1].Balance)
+	}
+
+	if validators[1].WithdrawalCredentials[0] != 0x01 || validators[1].WithdrawalCredentials[31] != 0xaa {
+		t.Fatalf("unexpected withdrawal credentials for validator 1: 0x%x", validators[1].WithdrawalCredentials)
+	}
+}
+
+func TestLoadValidatorsFromDepositData_WrongForkVersion(t *testing.T) {
+	wdCred := make([]byte, 32)
+	entry := createTestDepositDataEntry(t, 1, wdCred, 32000000000, phase0.Version{0x10, 0x00, 0x00, 0x38})
+	entry.ForkVersion = ""
+
+	depositDataFile := createTestDepositDataFile(t, []*DepositDataEntry{entry})
+
+	_, err := LoadValidatorsFromDepositData(depositDataFile, phase0.Version{0x00, 0x00, 0x00, 0x00})
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid signature") {
+		t.Fatalf("expected error to contain 'invalid signature', got %s", err)
+	}
+}
+
+func TestLoadValidatorsFromDepositData_ForkVersionMismatch(t *testing.T) {
+	wdCred := make([]byte, 32)
+	depositDataFile := createTestDepositDataFile(t, []*DepositDataEntry{
+		createTestDepositDataEntry(t, 1, wdCred, 32000000000, phase0.Version{0x10, 0x00, 0x00, 0x38}),
+	})
+
+	_, err := LoadValidatorsFromDepositData(depositDataFile, phase0.Version{0x00, 0x00, 0x00, 0x00})
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "fork_version mismatch") {
+		t.Fatalf("expected error to contain 'fork_version mismatch', got %s", err)
+	}
+}
+
+func TestLoadValidatorsFromDepositData_TamperedAmount(t *testing.T) {
+	forkVersion := phase0.Version{0x10, 0x00, 0x00, 0x38}
+	entry := createTestDepositDataEntry(t, 1, make([]byte, 32), 32000000000, forkVersion)
+	entry.Amount = 64000000000
+
+	depositDataFile := createTestDepositDataFile(t, []*DepositDataEntry{entry})
+
+	_, err := LoadValidatorsFromDepositData(depositDataFile, forkVersion)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid signature") {
+		t.Fatalf("expected error to contain 'invalid signature', got %s", err)
+	}
+}
+
+func TestLoadValidatorsFromDepositData_WrongDepositDataRoot(t *testing.T) {
+	forkVersion := phase0.Version{0x10, 0x00, 0x00, 0x38}
+	entry := createTestDepositDataEntry(t, 1, make([]byte, 32), 32000000000, forkVersion)
+	entry.DepositDataRoot = strings.Repeat("00", 32)
+
+	depositDataFile := createTestDepositDataFile(t, []*DepositDataEntry{entry})
+
+	_, err := LoadValidatorsFromDepositData(depositDataFile, forkVersion)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "deposit_data_root mismatch") {
+		t.Fatalf("expected error to contain 'deposit_data_root mismatch', got %s", err)
+	}
+}
+
+func TestLoadValidatorsFromDepositData_DuplicatePubkey(t *testing.T) {
+	forkVersion := phase0.Version{0x10, 0x00, 0x00, 0x38}
+	entry := createTestDepositDataEntry(t, 1, make([]byte, 32), 32000000000, forkVersion)
+
+	depositDataFile := createTestDepositDataFile(t, []*DepositDataEntry{entry, entry})
+
+	_, err := LoadValidatorsFromDepositData(depositDataFile, forkVersion)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "duplicate pubkey") {
+		t.Fatalf("expected error to contain 'duplicate pubkey', got %s", err)
+	}
+}
@@ -0,0 +1,199 @@
+package validators
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// hdKey is a BIP-32 extended secp256k1 private key.
+type hdKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+// newMasterHDKey derives the BIP-32 master key from a seed.
+func newMasterHDKey(seed []byte) (*hdKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	if _, err := crypto.ToECDSA(sum[:32]); err != nil {
+		return nil, fmt.Errorf("invalid master key: %w", err)
+	}
+
+	return &hdKey{
+		key:       sum[:32],
+		chainCode: sum[32:],
+	}, nil
+}
+
+// derive derives a child key (indexes >= 0x80000000 are hardened).
+func (k *hdKey) derive(index uint32) (*hdKey, error) {
+	data := make([]byte, 0, 37)
+
+	if index >= 0x80000000 {
+		data = append(data, 0x00)
+		data = append(data, k.key...)
+	} else {
+		privKey, err := crypto.ToECDSA(k.key)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, crypto.CompressPubkey(&privKey.PublicKey)...)
+	}
+
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	curveOrder := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+
+	if tweak.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("invalid child key at index %v", index)
+	}
+
+	childKey := tweak.Add(tweak, new(big.Int).SetBytes(k.key))
+	childKey.Mod(childKey, curveOrder)
+
+	if childKey.Sign() == 0 {
+		return nil, fmt.Errorf("invalid child key at index %v", index)
+	}
+
+	return &hdKey{
+		key:       childKey.FillBytes(make([]byte, 32)),
+		chainCode: sum[32:],
+	}, nil
+}
+
+func (k *hdKey) derivePath(path accounts.DerivationPath) (*hdKey, error) {
+	key := k
+
+	for _, index := range path {
+		child, err := key.derive(index)
+		if err != nil {
+			return nil, err
+		}
+
+		key = child
+	}
+
+	return key, nil
+}
+
+func (k *hdKey) address() (common.Address, error) {
+	privKey, err := crypto.ToECDSA(k.key)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(privKey.PublicKey), nil
+}
+
+// addressPathTemplate derives execution layer addresses from a derivation path template like m/44'/60'/0'/0/{index}.
+// The key for the path components before the placeholder is derived only once.
+type addressPathTemplate struct {
+	template   string
+	parentKey  *hdKey
+	suffixPath []string
+}
+
+func newAddressPathTemplate(seed []byte, template string) (*addressPathTemplate, error) {
+	if strings.Count(template, withdrawalAddressIndexPlaceholder) != 1 {
+		return nil, fmt.Errorf("invalid wd_address_path: must contain %v exactly once", withdrawalAddressIndexPlaceholder)
+	}
+
+	components := strings.Split(strings.TrimSpace(template), "/")
+	if len(components) < 2 || components[0] != "m" {
+		return nil, errors.New("invalid wd_address_path: must start with m/")
+	}
+
+	placeholderPos := 0
+
+	for i, component := range components {
+		if strings.Contains(component, withdrawalAddressIndexPlaceholder) {
+			placeholderPos = i
+			break
+		}
+	}
+
+	masterKey, err := newMasterHDKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	parentKey := masterKey
+
+	if placeholderPos > 1 {
+		parentPath, err := accounts.ParseDerivationPath(strings.Join(components[:placeholderPos], "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid wd_address_path: %w", err)
+		}
+
+		parentKey, err = masterKey.derivePath(parentPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pathTemplate := &addressPathTemplate{
+		template:   template,
+		parentKey:  parentKey,
+		suffixPath: components[placeholderPos:],
+	}
+
+	// validate the suffix format with a dummy index
+	if _, err := pathTemplate.parseSuffix(0); err != nil {
+		return nil, err
+	}
+
+	return pathTemplate, nil
+}
+
+// Path returns the full derivation path for the given index.
+func (t *addressPathTemplate) Path(idx uint64) string {
+	return strings.Replace(t.template, withdrawalAddressIndexPlaceholder, strconv.FormatUint(idx, 10), 1)
+}
+
+func (t *addressPathTemplate) parseSuffix(idx uint64) (accounts.DerivationPath, error) {
+	if idx >= 0x80000000 {
+		return nil, fmt.Errorf("invalid wd_address_path: index %v out of range", idx)
+	}
+
+	suffix := strings.Replace(strings.Join(t.suffixPath, "/"), withdrawalAddressIndexPlaceholder, strconv.FormatUint(idx, 10), 1)
+
+	suffixPath, err := accounts.ParseDerivationPath("m/" + suffix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wd_address_path: %w", err)
+	}
+
+	return suffixPath, nil
+}
+
+// Address derives the execution layer address for the given index.
+func (t *addressPathTemplate) Address(idx uint64) (common.Address, error) {
+	suffixPath, err := t.parseSuffix(idx)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	key, err := t.parentKey.derivePath(suffixPath)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return key.address()
+}
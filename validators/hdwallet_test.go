@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// well known development mnemonic (hardhat/anvil)
+const testHDMnemonic = "test test test test test test test test test test test junk"
+
+func TestAddressPathTemplate(t *testing.T) {
+	pathTemplate, err := newAddressPathTemplate(bip39.NewSeed(testHDMnemonic, ""), "m/44'/60'/0'/0/{index}")
+	if err != nil {
+		t.Fatalf("failed to create address path template: %v", err)
+	}
+
+	expected := []string{
+		"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+		"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+	}
+
+	for idx, expectedAddress := range expected {
+		address, err := pathTemplate.Address(uint64(idx))
+		if err != nil {
+			t.Fatalf("failed to derive address %d: %v", idx, err)
+		}
+
+		if address.Hex() != expectedAddress {
+			t.Fatalf("expected address %d to be %s, got %s", idx, expectedAddress, address.Hex())
+		}
+	}
+
+	if path := pathTemplate.Path(2); path != "m/44'/60'/0'/0/2" {
+		t.Fatalf("unexpected path: %s", path)
+	}
+}
+
+func TestAddressPathTemplate_Invalid(t *testing.T) {
+	seed := bip39.NewSeed(testHDMnemonic, "")
+
+	for _, template := range []string{"m/44'/60'/0'/0/0", "44'/60'/0'/0/{index}", "m/44'/60'/x/{index}", "m/{index}/{index}"} {
+		if _, err := newAddressPathTemplate(seed, template); err == nil {
+			t.Fatalf("expected error for template %s, got nil", template)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package validators
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	blsu "github.com/protolambda/bls12-381-util"
+	"golang.org/x/sync/errgroup"
+)
+
+// blsCurveOrder is the order of the BLS12-381 curve (r).
+var blsCurveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// InteropSecretKey returns the insecure deterministic interop secret key for the given validator index:
+// int.from_bytes(sha256(index.to_bytes(32, "little")), "little") % curve_order
+func InteropSecretKey(index uint64) (*blsu.SecretKey, error) {
+	indexBytes := make([]byte, 32)
+	binary.LittleEndian.PutUint64(indexBytes, index)
+
+	hash := sha256.Sum256(indexBytes)
+	slices.Reverse(hash[:]) // little endian to big endian
+
+	scalar := new(big.Int).SetBytes(hash[:])
+	scalar.Mod(scalar, blsCurveOrder)
+
+	var keyBytes [32]byte
+
+	scalar.FillBytes(keyBytes[:])
+
+	secretKey := &blsu.SecretKey{}
+	if err := secretKey.Deserialize(&keyBytes); err != nil {
+		return nil, fmt.Errorf("invalid interop secret key for index %v: %w", index, err)
+	}
+
+	return secretKey, nil
+}
+
+// interopValidator returns the interop secret key and validator for the given index.
+// The withdrawal credentials are the bls credentials of the signing key (0x00 + sha256(pubkey)[1:]).
+func interopValidator(index uint64) (*blsu.SecretKey, *Validator, error) {
+	secretKey, err := InteropSecretKey(index)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pubKey, err := blsu.SkToPk(secretKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive pubkey for index %v: %w", index, err)
+	}
+
+	pubKeyBytes := pubKey.Serialize()
+	withdrawalCred := sha256.Sum256(pubKeyBytes[:])
+	withdrawalCred[0] = 0x00
+
+	return secretKey, &Validator{
+		PublicKey:             phase0.BLSPubKey(pubKeyBytes),
+		WithdrawalCredentials: withdrawalCred[:],
+	}, nil
+}
+
+// GenerateInteropValidators generates the validators with the deterministic interop keys used by the clients' --interop modes.
+func GenerateInteropValidators(start, count uint64) ([]*Validator, error) {
+	validators := make([]*Validator, count)
+
+	var g errgroup.Group
+
+	g.SetLimit(10_000)
+
+	for i := uint64(0); i < count; i++ {
+		g.Go(func() error {
+			_, validator, err := interopValidator(start + i)
+			if err != nil {
+				return err
+			}
+
+			validators[i] = validator
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return validators, nil
+}
+
+// GenerateInteropDepositData generates the signed genesis deposits of the interop validators.
+func GenerateInteropDepositData(start, count, amount uint64, genesisForkVersion phase0.Version) ([]*phase0.DepositData, error) {
+	domain, err := ComputeDepositDomain(genesisForkVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	depositData := make([]*phase0.DepositData, count)
+
+	var g errgroup.Group
+
+	g.SetLimit(10_000)
+
+	for i := uint64(0); i < count; i++ {
+		g.Go(func() error {
+			secretKey, validator, err := interopValidator(start + i)
+			if err != nil {
+				return err
+			}
+
+			deposit := &phase0.DepositData{
+				PublicKey:             validator.PublicKey,
+				WithdrawalCredentials: validator.WithdrawalCredentials,
+				Amount:                phase0.Gwei(amount),
+			}
+
+			signingRoot, err := depositSigningRoot(deposit, domain)
+			if err != nil {
+				return err
+			}
+
+			deposit.Signature = phase0.BLSSignature(blsu.Sign(secretKey, signingRoot[:]).Serialize())
+			depositData[i] = deposit
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return depositData, nil
+}
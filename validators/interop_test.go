@@ -0,0 +1,71 @@
+package validators
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func TestGenerateInteropValidators(t *testing.T) {
+	// keys from the interop mocked start keygen vectors
+	secretKey, err := InteropSecretKey(0)
+	if err != nil {
+		t.Fatalf("failed to derive interop secret key: %v", err)
+	}
+
+	secretKeyBytes := secretKey.Serialize()
+	if hex.EncodeToString(secretKeyBytes[:]) != "25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866" {
+		t.Fatalf("unexpected interop secret key 0: %x", secretKeyBytes)
+	}
+
+	validators, err := GenerateInteropValidators(0, 2)
+	if err != nil {
+		t.Fatalf("failed to generate interop validators: %v", err)
+	}
+
+	if validators[0].PublicKey.String() != "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c" {
+		t.Fatalf("unexpected interop pubkey 0: %v", validators[0].PublicKey.String())
+	}
+
+	if validators[1].PublicKey.String() != "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b" {
+		t.Fatalf("unexpected interop pubkey 1: %v", validators[1].PublicKey.String())
+	}
+
+	if validators[0].WithdrawalCredentials[0] != 0x00 || validators[0].Balance != nil {
+		t.Fatalf("unexpected interop validator settings: credentials 0x%x", validators[0].WithdrawalCredentials)
+	}
+
+	offsetValidators, err := GenerateInteropValidators(1, 1)
+	if err != nil {
+		t.Fatalf("failed to generate interop validators: %v", err)
+	}
+
+	if offsetValidators[0].PublicKey != validators[1].PublicKey {
+		t.Fatalf("expected start offset to select interop key 1")
+	}
+}
+
+func TestGenerateInteropDepositData(t *testing.T) {
+	forkVersion := phase0.Version{0x00, 0x00, 0x00, 0x01}
+
+	depositData, err := GenerateInteropDepositData(0, 3, 32000000000, forkVersion)
+	if err != nil {
+		t.Fatalf("failed to generate interop deposits: %v", err)
+	}
+
+	domain, err := ComputeDepositDomain(forkVersion)
+	if err != nil {
+		t.Fatalf("failed to compute deposit domain: %v", err)
+	}
+
+	for i, deposit := range depositData {
+		if err := verifyDepositData(deposit, domain); err != nil {
+			t.Fatalf("invalid interop deposit %d: %v", i, err)
+		}
+
+		if deposit.Amount != 32000000000 {
+			t.Fatalf("unexpected amount for interop deposit %d: %v", i, deposit.Amount)
+		}
+	}
+}
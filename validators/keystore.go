@@ -0,0 +1,304 @@
+package validators
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+// keystore encryption parameters (EIP-2335 defaults)
+const (
+	keystoreScryptN = 262144
+	keystoreScryptR = 8
+	keystoreScryptP = 1
+	keystoreDKLen   = 32
+	// insecureKeystorePbkdf2C is the pbkdf2 iteration count of insecure keystores, which are fast to encrypt and decrypt.
+	insecureKeystorePbkdf2C = 2
+)
+
+// Keystore is an EIP-2335 BLS12-381 keystore.
+type Keystore struct {
+	Crypto      *KeystoreCrypto `json:"crypto"`
+	Description string          `json:"description,omitempty"`
+	PubKey      string          `json:"pubkey"`
+	Path        string          `json:"path"`
+	UUID        string          `json:"uuid"`
+	Version     uint64          `json:"version"`
+}
+
+type KeystoreCrypto struct {
+	KDF      *KeystoreModule `json:"kdf"`
+	Checksum *KeystoreModule `json:"checksum"`
+	Cipher   *KeystoreModule `json:"cipher"`
+}
+
+type KeystoreModule struct {
+	Function string                     `json:"function"`
+	Params   map[string]json.RawMessage `json:"params"`
+	Message  string                     `json:"message"`
+}
+
+// Decrypt decrypts the keystore secret with the given password.
+func (ks *Keystore) Decrypt(password string) ([]byte, error) {
+	if ks.Crypto == nil || ks.Crypto.KDF == nil || ks.Crypto.Checksum == nil || ks.Crypto.Cipher == nil {
+		return nil, errors.New("incomplete crypto section")
+	}
+
+	decryptionKey, err := ks.Crypto.deriveKey(normalizeKeystorePassword(password))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(decryptionKey) < 32 {
+		return nil, fmt.Errorf("derived key too short (%v bytes)", len(decryptionKey))
+	}
+
+	cipherMessage, err := hex.DecodeString(ks.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cipher message: %w", err)
+	}
+
+	if ks.Crypto.Checksum.Function != "sha256" {
+		return nil, fmt.Errorf("unsupported checksum function: %v", ks.Crypto.Checksum.Function)
+	}
+
+	checksum, err := hex.DecodeString(ks.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum message: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write(decryptionKey[16:32])
+	h.Write(cipherMessage)
+
+	if !bytes.Equal(h.Sum(nil), checksum) {
+		return nil, errors.New("invalid password (checksum mismatch)")
+	}
+
+	if ks.Crypto.Cipher.Function != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported cipher function: %v", ks.Crypto.Cipher.Function)
+	}
+
+	iv, err := ks.Crypto.Cipher.getHexParam("iv")
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(decryptionKey[:16])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iv) != block.BlockSize() {
+		return nil, fmt.Errorf("invalid iv length (%v bytes)", len(iv))
+	}
+
+	secret := make([]byte, len(cipherMessage))
+	cipher.NewCTR(block, iv).XORKeyStream(secret, cipherMessage)
+
+	return secret, nil
+}
+
+// EncryptKeystore encrypts a secret key into an EIP-2335 keystore (scrypt, aes-128-ctr).
+// Insecure keystores use pbkdf2 with a minimal iteration count, which is only suitable for test networks.
+func EncryptKeystore(secret []byte, password, path string, insecure bool) (*Keystore, error) {
+	pubkey, err := pubkeyFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	randomData := make([]byte, 32+16+16)
+	if _, err := rand.Read(randomData); err != nil {
+		return nil, fmt.Errorf("failed to generate random data: %w", err)
+	}
+
+	salt, iv, uuid := randomData[:32], randomData[32:48], randomData[48:]
+
+	kdf := &KeystoreModule{
+		Function: "scrypt",
+		Params: map[string]json.RawMessage{
+			"dklen": jsonParam(keystoreDKLen),
+			"n":     jsonParam(keystoreScryptN),
+			"r":     jsonParam(keystoreScryptR),
+			"p":     jsonParam(keystoreScryptP),
+			"salt":  jsonParam(hex.EncodeToString(salt)),
+		},
+	}
+
+	if insecure {
+		kdf = &KeystoreModule{
+			Function: "pbkdf2",
+			Params: map[string]json.RawMessage{
+				"dklen": jsonParam(keystoreDKLen),
+				"c":     jsonParam(insecureKeystorePbkdf2C),
+				"prf":   jsonParam("hmac-sha256"),
+				"salt":  jsonParam(hex.EncodeToString(salt)),
+			},
+		}
+	}
+
+	crypto := &KeystoreCrypto{
+		KDF: kdf,
+		Cipher: &KeystoreModule{
+			Function: "aes-128-ctr",
+			Params: map[string]json.RawMessage{
+				"iv": jsonParam(hex.EncodeToString(iv)),
+			},
+		},
+	}
+
+	encryptionKey, err := crypto.deriveKey(normalizeKeystorePassword(password))
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(encryptionKey[:16])
+	if err != nil {
+		return nil, err
+	}
+
+	cipherMessage := make([]byte, len(secret))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherMessage, secret)
+
+	h := sha256.New()
+	h.Write(encryptionKey[16:32])
+	h.Write(cipherMessage)
+
+	crypto.Cipher.Message = hex.EncodeToString(cipherMessage)
+	crypto.Checksum = &KeystoreModule{
+		Function: "sha256",
+		Params:   map[string]json.RawMessage{},
+		Message:  hex.EncodeToString(h.Sum(nil)),
+	}
+
+	// random (version 4) UUID
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+	return &Keystore{
+		Crypto:  crypto,
+		PubKey:  hex.EncodeToString(pubkey),
+		Path:    path,
+		UUID:    fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16]),
+		Version: 4,
+	}, nil
+}
+
+func jsonParam(value interface{}) json.RawMessage {
+	data, _ := json.Marshal(value)
+
+	return data
+}
+
+func (c *KeystoreCrypto) deriveKey(password []byte) ([]byte, error) {
+	salt, err := c.KDF.getHexParam("salt")
+	if err != nil {
+		return nil, err
+	}
+
+	dkLen, err := c.KDF.getUintParam("dklen")
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.KDF.Function {
+	case "scrypt":
+		n, err := c.KDF.getUintParam("n")
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := c.KDF.getUintParam("r")
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := c.KDF.getUintParam("p")
+		if err != nil {
+			return nil, err
+		}
+
+		return scrypt.Key(password, salt, int(n), int(r), int(p), int(dkLen)) //nolint:gosec // no overflow
+	case "pbkdf2":
+		iterations, err := c.KDF.getUintParam("c")
+		if err != nil {
+			return nil, err
+		}
+
+		var prf string
+		if err := c.KDF.getParam("prf", &prf); err != nil {
+			return nil, err
+		}
+
+		if prf != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported pbkdf2 prf: %v", prf)
+		}
+
+		return pbkdf2.Key(password, salt, int(iterations), int(dkLen), sha256.New), nil //nolint:gosec // no overflow
+	default:
+		return nil, fmt.Errorf("unsupported kdf function: %v", c.KDF.Function)
+	}
+}
+
+func (m *KeystoreModule) getParam(name string, target interface{}) error {
+	raw, ok := m.Params[name]
+	if !ok {
+		return fmt.Errorf("missing %v param %v", m.Function, name)
+	}
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("invalid %v param %v: %w", m.Function, name, err)
+	}
+
+	return nil
+}
+
+func (m *KeystoreModule) getUintParam(name string) (uint64, error) {
+	var value uint64
+
+	err := m.getParam(name, &value)
+
+	return value, err
+}
+
+func (m *KeystoreModule) getHexParam(name string) ([]byte, error) {
+	var value string
+	if err := m.getParam(name, &value); err != nil {
+		return nil, err
+	}
+
+	bytes, err := hex.DecodeString(strings.ReplaceAll(value, "0x", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v param %v: %w", m.Function, name, err)
+	}
+
+	return bytes, nil
+}
+
+// normalizeKeystorePassword applies the EIP-2335 password processing:
+// NFKD normalization followed by stripping of all control codes.
+func normalizeKeystorePassword(password string) []byte {
+	normalized := norm.NFKD.String(password)
+	result := make([]byte, 0, len(normalized))
+
+	for _, r := range normalized {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			continue
+		}
+
+		result = append(result, string(r)...)
+	}
+
+	return result
+}
@@ -0,0 +1,298 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	blsu "github.com/protolambda/bls12-381-util"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// KeystoreSrc describes a directory of EIP-2335 keystores used as validator source.
+type KeystoreSrc struct {
+	Dir          string           `yaml:"dir"`
+	Defaults     KeystoreDefaults `yaml:"defaults"`
+	SidecarFile  string           `yaml:"sidecar_file"`
+	Password     string           `yaml:"password"`
+	PasswordFile string           `yaml:"password_file"`
+	SecretsDir   string           `yaml:"secrets_dir"`
+	sidecar      map[string]*KeystoreDefaults
+}
+
+// KeystoreDefaults holds the withdrawal credentials and balance applied to keystores.
+// It's used as defaults block of a keystore source and as per-pubkey entry of a sidecar file.
+type KeystoreDefaults struct {
+	WithdrawalCredentials string  `yaml:"withdrawal_credentials" json:"withdrawal_credentials"`
+	Balance               *uint64 `yaml:"balance" json:"balance"`
+}
+
+// LoadValidatorsFromKeystores loads validators from the keystore directories described in a keystores config file.
+func LoadValidatorsFromKeystores(keystoresConfigPath string) ([]*Validator, error) {
+	keystoreSrcs, err := loadKeystoreSrcs(keystoresConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]*Validator, 0)
+	pubkeyMap := map[string]string{}
+
+	for s, keystoreSrc := range keystoreSrcs {
+		if keystoreSrc.Dir == "" {
+			return nil, fmt.Errorf("keystore source %d has no dir", s)
+		}
+
+		err := filepath.WalkDir(keystoreSrc.Dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+				return nil
+			}
+
+			validator, err := keystoreSrc.loadValidator(path)
+			if err != nil {
+				return fmt.Errorf("keystore %v: %w", path, err)
+			}
+
+			if validator == nil {
+				return nil
+			}
+
+			if otherPath, exists := pubkeyMap[string(validator.PublicKey[:])]; exists {
+				return fmt.Errorf("duplicate pubkey in keystore %v and %v", otherPath, path)
+			}
+
+			pubkeyMap[string(validator.PublicKey[:])] = path
+
+			validators = append(validators, validator)
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("keystore source %d: %w", s, err)
+		}
+
+		logrus.Infof("loaded keystores from %v", keystoreSrc.Dir)
+	}
+
+	return validators, nil
+}
+
+func loadKeystoreSrcs(srcPath string) ([]*KeystoreSrc, error) {
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var keystoreSrcs []*KeystoreSrc
+	if err := yaml.Unmarshal(data, &keystoreSrcs); err != nil {
+		return nil, err
+	}
+
+	baseDir := filepath.Dir(srcPath)
+
+	for s, keystoreSrc := range keystoreSrcs {
+		keystoreSrc.Dir = resolvePath(baseDir, keystoreSrc.Dir)
+		keystoreSrc.SidecarFile = resolvePath(baseDir, keystoreSrc.SidecarFile)
+		keystoreSrc.PasswordFile = resolvePath(baseDir, keystoreSrc.PasswordFile)
+		keystoreSrc.SecretsDir = resolvePath(baseDir, keystoreSrc.SecretsDir)
+
+		if keystoreSrc.PasswordFile != "" {
+			password, err := os.ReadFile(keystoreSrc.PasswordFile)
+			if err != nil {
+				return nil, fmt.Errorf("keystore source %d: failed to read password file: %w", s, err)
+			}
+
+			keystoreSrc.Password = strings.TrimRight(string(password), "\r\n")
+		}
+
+		if keystoreSrc.SidecarFile != "" {
+			sidecarData, err := os.ReadFile(keystoreSrc.SidecarFile)
+			if err != nil {
+				return nil, fmt.Errorf("keystore source %d: failed to read sidecar file: %w", s, err)
+			}
+
+			sidecar := map[string]*KeystoreDefaults{}
+			if err := yaml.Unmarshal(sidecarData, &sidecar); err != nil {
+				return nil, fmt.Errorf("keystore source %d: failed to parse sidecar file: %w", s, err)
+			}
+
+			keystoreSrc.sidecar = make(map[string]*KeystoreDefaults, len(sidecar))
+			for pubkey, entry := range sidecar {
+				keystoreSrc.sidecar[strings.ToLower(strings.ReplaceAll(pubkey, "0x", ""))] = entry
+			}
+		}
+	}
+
+	return keystoreSrcs, nil
+}
+
+func resolvePath(baseDir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(baseDir, path)
+}
+
+func (src *KeystoreSrc) loadValidator(path string) (*Validator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if data = bytes.TrimSpace(data); len(data) == 0 || data[0] != '{' {
+		// not a keystore (e.g. the deposit data array written by the staking-deposit-cli)
+		logrus.Debugf("skipping non-keystore file %v", path)
+		return nil, nil
+	}
+
+	keystore := &Keystore{}
+	if err := json.Unmarshal(data, keystore); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore: %w", err)
+	}
+
+	if keystore.Crypto == nil {
+		// not a keystore (e.g. client config files)
+		logrus.Debugf("skipping non-keystore file %v", path)
+		return nil, nil
+	}
+
+	if keystore.Version != 4 {
+		return nil, fmt.Errorf("unsupported keystore version %v", keystore.Version)
+	}
+
+	var pubkey []byte
+
+	if keystore.PubKey != "" {
+		pubkey, err = hex.DecodeString(strings.ReplaceAll(keystore.PubKey, "0x", ""))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pubkey: %w", err)
+		}
+
+		if len(pubkey) != 48 {
+			return nil, fmt.Errorf("invalid pubkey (invalid length)")
+		}
+	}
+
+	password, hasPassword, err := src.getPassword(path, pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasPassword {
+		secret, err := keystore.Decrypt(password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+		}
+
+		secretPubkey, err := pubkeyFromSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		if pubkey == nil {
+			pubkey = secretPubkey
+		} else if !bytes.Equal(pubkey, secretPubkey) {
+			return nil, fmt.Errorf("pubkey mismatch (keystore: 0x%x, secret key: 0x%x)", pubkey, secretPubkey)
+		}
+	} else if pubkey == nil {
+		return nil, fmt.Errorf("keystore has no pubkey and no password provided")
+	}
+
+	validator := &Validator{
+		PublicKey:             phase0.BLSPubKey(pubkey),
+		WithdrawalCredentials: make([]byte, 32),
+	}
+
+	settings := src.Defaults
+	if sidecarEntry := src.sidecar[hex.EncodeToString(pubkey)]; sidecarEntry != nil {
+		if sidecarEntry.WithdrawalCredentials != "" {
+			settings.WithdrawalCredentials = sidecarEntry.WithdrawalCredentials
+		}
+
+		if sidecarEntry.Balance != nil {
+			settings.Balance = sidecarEntry.Balance
+		}
+	}
+
+	if settings.WithdrawalCredentials == "" {
+		return nil, fmt.Errorf("no withdrawal credentials for pubkey 0x%x", pubkey)
+	}
+
+	withdrawalCred, err := hex.DecodeString(strings.ReplaceAll(settings.WithdrawalCredentials, "0x", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid withdrawal credentials: %w", err)
+	}
+
+	if err := checkWithdrawalCredentials(withdrawalCred); err != nil {
+		return nil, fmt.Errorf("invalid withdrawal credentials (%v)", err)
+	}
+
+	copy(validator.WithdrawalCredentials, withdrawalCred)
+
+	if settings.Balance != nil {
+		balance := *settings.Balance
+		validator.Balance = &balance
+	}
+
+	return validator, nil
+}
+
+// getPassword returns the password for a keystore.
+// Passwords from the secrets dir are looked up by pubkey (with or without 0x prefix) or keystore file name.
+func (src *KeystoreSrc) getPassword(path string, pubkey []byte) (string, bool, error) {
+	if src.SecretsDir != "" {
+		candidates := []string{
+			strings.TrimSuffix(filepath.Base(path), ".json") + ".txt",
+		}
+
+		if pubkey != nil {
+			candidates = append(candidates, fmt.Sprintf("0x%x", pubkey), fmt.Sprintf("%x", pubkey))
+		}
+
+		for _, candidate := range candidates {
+			password, err := os.ReadFile(filepath.Join(src.SecretsDir, candidate))
+			if err == nil {
+				return strings.TrimRight(string(password), "\r\n"), true, nil
+			} else if !os.IsNotExist(err) {
+				return "", false, fmt.Errorf("failed to read secret: %w", err)
+			}
+		}
+
+		if src.Password == "" {
+			return "", false, fmt.Errorf("no secret found in %v", src.SecretsDir)
+		}
+	}
+
+	return src.Password, src.Password != "", nil
+}
+
+func pubkeyFromSecret(secret []byte) ([]byte, error) {
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("invalid secret key length (%v bytes)", len(secret))
+	}
+
+	var secretKey blsu.SecretKey
+	if err := secretKey.Deserialize((*[32]byte)(secret)); err != nil {
+		return nil, fmt.Errorf("invalid secret key: %w", err)
+	}
+
+	pubkey, err := blsu.SkToPk(&secretKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive pubkey: %w", err)
+	}
+
+	serialized := pubkey.Serialize()
+
+	return serialized[:], nil
+}
@@ -0,0 +1,231 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// EIP-2335 pbkdf2 test vector
+const testKeystorePassword = "𝔱𝔢𝔰𝔱𝔭𝔞𝔰𝔰𝔴𝔬𝔯𝔡🔑"
+const testKeystorePubkey = "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07"
+const testKeystore = `{
+    "crypto": {
+        "kdf": {
+            "function": "pbkdf2",
+            "params": {
+                "dklen": 32,
+                "c": 262144,
+                "prf": "hmac-sha256",
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+        }
+    },
+    "description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/0/0",
+    "uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+    "version": 4
+}`
+
+func createTestKeystoresDir(t *testing.T, config string, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("failed to write %v: %v", name, err)
+		}
+	}
+
+	configPath := filepath.Join(dir, "keystores.yaml")
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("failed to write keystores config: %v", err)
+	}
+
+	return configPath
+}
+
+func TestKeystoreDecrypt(t *testing.T) {
+	keystore := &Keystore{}
+	if err := json.Unmarshal([]byte(testKeystore), keystore); err != nil {
+		t.Fatalf("failed to parse keystore: %v", err)
+	}
+
+	secret, err := keystore.Decrypt(testKeystorePassword)
+	if err != nil {
+		t.Fatalf("failed to decrypt keystore: %v", err)
+	}
+
+	if value, _ := hex.DecodeString("000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"); !bytes.Equal(secret, value) {
+		t.Fatalf("unexpected secret: 0x%x", secret)
+	}
+
+	if _, err := keystore.Decrypt("wrong password"); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch error, got %v", err)
+	}
+}
+
+func TestLoadValidatorsFromKeystores_Defaults(t *testing.T) {
+	configPath := createTestKeystoresDir(t, `
+- dir: keys
+  defaults:
+    withdrawal_credentials: "0x0100000000000000000000001234567890abcdef1234567890abcdef12345678"
+    balance: 64000000000
+`, map[string]string{
+		"keys/keystore-m_12381_3600_0_0_0.json": testKeystore,
+		"keys/validator_definitions.json":       `{"enabled": true}`,
+	})
+
+	validators, err := LoadValidatorsFromKeystores(configPath)
+	if err != nil {
+		t.Fatalf("failed to load validators from keystores: %v", err)
+	}
+
+	if len(validators) != 1 {
+		t.Fatalf("expected 1 validator, got %d", len(validators))
+	}
+
+	if value, _ := hex.DecodeString(testKeystorePubkey); !bytes.Equal(validators[0].PublicKey[:], value) {
+		t.Fatalf("unexpected pubkey: %s", validators[0].PublicKey.String())
+	}
+
+	if value, _ := hex.DecodeString("0100000000000000000000001234567890abcdef1234567890abcdef12345678"); !bytes.Equal(validators[0].WithdrawalCredentials, value) {
+		t.Fatalf("unexpected withdrawal credentials: 0x%x", validators[0].WithdrawalCredentials)
+	}
+
+	if validators[0].Balance == nil || *validators[0].Balance != 64000000000 {
+		t.Fatalf("expected balance 64000000000, got %v", validators[0].Balance)
+	}
+}
+
+func TestLoadValidatorsFromKeystores_DepositCliDir(t *testing.T) {
+	// validator_keys directory as written by the staking-deposit-cli
+	configPath := createTestKeystoresDir(t, `
+- dir: validator_keys
+  defaults:
+    withdrawal_credentials: "0x0100000000000000000000001234567890abcdef1234567890abcdef12345678"
+`, map[string]string{
+		"validator_keys/keystore-m_12381_3600_0_0_0-1700000000.json": testKeystore,
+		"validator_keys/deposit_data-1700000000.json":                `[{"pubkey": "` + testKeystorePubkey + `", "amount": 32000000000}]`,
+	})
+
+	validators, err := LoadValidatorsFromKeystores(configPath)
+	if err != nil {
+		t.Fatalf("failed to load validators from keystores: %v", err)
+	}
+
+	if len(validators) != 1 {
+		t.Fatalf("expected 1 validator, got %d", len(validators))
+	}
+}
+
+func TestLoadValidatorsFromKeystores_SidecarAndSecrets(t *testing.T) {
+	configPath := createTestKeystoresDir(t, `
+- dir: keys
+  sidecar_file: withdrawals.yaml
+  secrets_dir: secrets
+`, map[string]string{
+		"keys/keystore.json":              testKeystore,
+		"secrets/0x" + testKeystorePubkey: testKeystorePassword,
+		"withdrawals.yaml":                "0x" + testKeystorePubkey + ":\n  withdrawal_credentials: \"0x020000000000000000000000000000000000000000000000000000000000dEaD\"\n",
+	})
+
+	validators, err := LoadValidatorsFromKeystores(configPath)
+	if err != nil {
+		t.Fatalf("failed to load validators from keystores: %v", err)
+	}
+
+	if len(validators) != 1 {
+		t.Fatalf("expected 1 validator, got %d", len(validators))
+	}
+
+	if validators[0].WithdrawalCredentials[0] != 0x02 || validators[0].WithdrawalCredentials[31] != 0xad {
+		t.Fatalf("unexpected withdrawal credentials: 0x%x", validators[0].WithdrawalCredentials)
+	}
+
+	if validators[0].Balance != nil {
+		t.Fatalf("expected no balance, got %d", *validators[0].Balance)
+	}
+}
+
+func TestLoadValidatorsFromKeystores_PubkeyMismatch(t *testing.T) {
+	tampered := strings.Replace(testKeystore, `"pubkey": "9612d7`, `"pubkey": "a612d7`, 1)
+	configPath := createTestKeystoresDir(t, `
+- dir: keys
+  password: "`+testKeystorePassword+`"
+  defaults:
+    withdrawal_credentials: "0x0100000000000000000000001234567890abcdef1234567890abcdef12345678"
+`, map[string]string{
+		"keys/keystore.json": tampered,
+	})
+
+	_, err := LoadValidatorsFromKeystores(configPath)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "pubkey mismatch") {
+		t.Fatalf("expected error to contain 'pubkey mismatch', got %s", err)
+	}
+}
+
+func TestLoadValidatorsFromKeystores_MissingCredentials(t *testing.T) {
+	configPath := createTestKeystoresDir(t, `
+- dir: keys
+`, map[string]string{
+		"keys/keystore.json": testKeystore,
+	})
+
+	_, err := LoadValidatorsFromKeystores(configPath)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "no withdrawal credentials") {
+		t.Fatalf("expected error to contain 'no withdrawal credentials', got %s", err)
+	}
+}
+
+func TestLoadValidatorsFromKeystores_DuplicatePubkey(t *testing.T) {
+	configPath := createTestKeystoresDir(t, `
+- dir: keys
+  defaults:
+    withdrawal_credentials: "0x0100000000000000000000001234567890abcdef1234567890abcdef12345678"
+`, map[string]string{
+		"keys/a/keystore.json": testKeystore,
+		"keys/b/keystore.json": testKeystore,
+	})
+
+	_, err := LoadValidatorsFromKeystores(configPath)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "duplicate pubkey") {
+		t.Fatalf("expected error to contain 'duplicate pubkey', got %s", err)
+	}
+}
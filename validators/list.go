@@ -6,24 +6,38 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
+// LoadValidatorsFromFile loads validators from a validators list file.
+// The list format (legacy colon-delimited text, YAML, JSON or CSV) is auto-detected.
 func LoadValidatorsFromFile(validatorsConfigPath string) ([]*Validator, error) {
-	validatorsFile, err := os.Open(validatorsConfigPath)
+	data, err := os.ReadFile(validatorsConfigPath)
 	if err != nil {
 		return nil, err
 	}
 
-	defer validatorsFile.Close()
+	switch detectListFormat(validatorsConfigPath, data) {
+	case listFormatStructured:
+		return parseStructuredValidatorsList(data, filepath.Dir(validatorsConfigPath))
+	case listFormatCSV:
+		return parseCSVValidatorsList(data)
+	default:
+		return parseLegacyValidatorsList(data)
+	}
+}
 
+// parseLegacyValidatorsList parses the colon-delimited text format:
+// <validator pubkey>:<withdrawal credentials>[:<balance>]
+func parseLegacyValidatorsList(data []byte) ([]*Validator, error) {
 	validators := make([]*Validator, 0)
 	pubkeyMap := map[string]int{}
 
-	scanner := bufio.NewScanner(validatorsFile)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 	lineNum := 0
 
 	for scanner.Scan() {
@@ -62,18 +76,8 @@ func LoadValidatorsFromFile(validatorsConfigPath string) ([]*Validator, error) {
 			return nil, err
 		}
 
-		if len(withdrawalCred) != 32 {
-			return nil, fmt.Errorf("invalid withdrawal credentials (invalid length) on line %v", lineNum)
-		}
-
-		switch withdrawalCred[0] {
-		case 0x00:
-		case 0x01, 0x02:
-			if !bytes.Equal(withdrawalCred[1:12], []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}) {
-				return nil, fmt.Errorf("invalid withdrawal credentials (invalid 0x01/0x02 cred) on line %v", lineNum)
-			}
-		default:
-			return nil, fmt.Errorf("invalid withdrawal credentials (invalid type) on line %v", lineNum)
+		if err := checkWithdrawalCredentials(withdrawalCred); err != nil {
+			return nil, fmt.Errorf("invalid withdrawal credentials (%v) on line %v", err, lineNum)
 		}
 
 		copy(validatorEntry.WithdrawalCredentials, withdrawalCred)
@@ -0,0 +1,394 @@
+package validators
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+type listFormat int
+
+const (
+	listFormatLegacy listFormat = iota
+	listFormatStructured
+	listFormatCSV
+)
+
+// csvMetadataPrefix is the column prefix for metadata fields in CSV lists.
+const csvMetadataPrefix = "metadata."
+
+var legacyListLinePattern = regexp.MustCompile(`^(0x)?[0-9a-fA-F]+:`)
+
+// detectListFormat detects the format of a validators list by content or file extension.
+// Legacy lines are detected by content first, so legacy lists keep working regardless of the file extension.
+func detectListFormat(path string, data []byte) listFormat {
+	firstLine := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			firstLine = line
+			break
+		}
+	}
+
+	if legacyListLinePattern.MatchString(firstLine) {
+		return listFormatLegacy
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return listFormatStructured
+	case ".csv":
+		return listFormatCSV
+	}
+
+	switch {
+	case strings.HasPrefix(firstLine, "[") || strings.HasPrefix(firstLine, "{") || strings.HasPrefix(firstLine, "-") || strings.HasPrefix(firstLine, "validators:"):
+		return listFormatStructured
+	case strings.Contains(firstLine, ",") && strings.Contains(strings.ToLower(firstLine), "pubkey"):
+		return listFormatCSV
+	default:
+		return listFormatLegacy
+	}
+}
+
+// listEntry is a single entry of a structured validators list.
+type listEntry struct {
+	line                  int
+	fieldLines            map[string]int
+	PubKey                string
+	WithdrawalCredentials string
+	WithdrawalAddress     string
+	WithdrawalType        string
+	Balance               string
+	Label                 string
+	Metadata              map[string]string
+}
+
+func (e *listEntry) fieldError(field string, err error) error {
+	line := e.line
+	if fieldLine, ok := e.fieldLines[field]; ok {
+		line = fieldLine
+	}
+
+	return fmt.Errorf("invalid %v on line %v: %w", field, line, err)
+}
+
+func (e *listEntry) toValidator() (*Validator, error) {
+	if e.PubKey == "" {
+		return nil, e.fieldError("pubkey", errors.New("missing value"))
+	}
+
+	pubKey, err := hex.DecodeString(strings.ReplaceAll(e.PubKey, "0x", ""))
+	if err != nil {
+		return nil, e.fieldError("pubkey", err)
+	}
+
+	if len(pubKey) != 48 {
+		return nil, e.fieldError("pubkey", errors.New("invalid length"))
+	}
+
+	validator := &Validator{
+		PublicKey:             phase0.BLSPubKey(pubKey),
+		WithdrawalCredentials: make([]byte, 32),
+		Label:                 e.Label,
+		Metadata:              e.Metadata,
+	}
+
+	switch {
+	case e.WithdrawalCredentials != "" && (e.WithdrawalAddress != "" || e.WithdrawalType != ""):
+		return nil, e.fieldError("withdrawal_credentials", errors.New("cannot be combined with withdrawal_address or withdrawal_type"))
+	case e.WithdrawalCredentials != "":
+		withdrawalCred, err := hex.DecodeString(strings.ReplaceAll(e.WithdrawalCredentials, "0x", ""))
+		if err != nil {
+			return nil, e.fieldError("withdrawal_credentials", err)
+		}
+
+		if err := checkWithdrawalCredentials(withdrawalCred); err != nil {
+			return nil, e.fieldError("withdrawal_credentials", err)
+		}
+
+		copy(validator.WithdrawalCredentials, withdrawalCred)
+	case e.WithdrawalAddress != "":
+		if e.WithdrawalType == "" {
+			return nil, e.fieldError("withdrawal_type", errors.New("missing value (required with withdrawal_address)"))
+		}
+
+		wdPrefix, err := parseWithdrawalType(e.WithdrawalType)
+		if err != nil {
+			return nil, e.fieldError("withdrawal_type", err)
+		}
+
+		if wdPrefix == 0x00 {
+			return nil, e.fieldError("withdrawal_type", errors.New("bls credentials cannot be derived from an address, use withdrawal_credentials"))
+		}
+
+		address, err := hex.DecodeString(strings.ReplaceAll(e.WithdrawalAddress, "0x", ""))
+		if err != nil {
+			return nil, e.fieldError("withdrawal_address", err)
+		}
+
+		if len(address) != 20 {
+			return nil, e.fieldError("withdrawal_address", errors.New("invalid length"))
+		}
+
+		validator.WithdrawalCredentials[0] = wdPrefix
+		copy(validator.WithdrawalCredentials[12:], address)
+	default:
+		return nil, e.fieldError("withdrawal_credentials", errors.New("missing value (withdrawal_credentials or withdrawal_address required)"))
+	}
+
+	if e.Balance != "" {
+		balance, err := strconv.ParseUint(e.Balance, 10, 64)
+		if err != nil {
+			return nil, e.fieldError("balance", err)
+		}
+
+		validator.Balance = &balance
+	}
+
+	return validator, nil
+}
+
+// parseStructuredValidatorsList parses a YAML or JSON list of validator objects.
+// JSON is parsed via the YAML parser, which keeps line information for error messages.
+// If the list is wrapped in a "validators" key, an optional "balance_distribution" applies to all entries without balance
+// and an optional "name" is used as source name of all entries.
+func parseStructuredValidatorsList(data []byte, baseDir string) ([]*Validator, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse validators list: %w", err)
+	}
+
+	if len(root.Content) == 0 {
+		return []*Validator{}, nil
+	}
+
+	var distribution *BalanceDistribution
+
+	var sourceName string
+
+	listNode := root.Content[0]
+	if listNode.Kind == yaml.MappingNode {
+		wrapperNode := listNode
+
+		// allow wrapping the list in a "validators" key
+		for i := 0; i+1 < len(wrapperNode.Content); i += 2 {
+			switch wrapperNode.Content[i].Value {
+			case "validators":
+				listNode = wrapperNode.Content[i+1]
+			case "name":
+				if wrapperNode.Content[i+1].Kind != yaml.ScalarNode {
+					return nil, fmt.Errorf("invalid name on line %v: expected a scalar value", wrapperNode.Content[i].Line)
+				}
+
+				sourceName = wrapperNode.Content[i+1].Value
+			case "balance_distribution":
+				distribution = &BalanceDistribution{}
+				if err := wrapperNode.Content[i+1].Decode(distribution); err != nil {
+					return nil, fmt.Errorf("invalid balance_distribution on line %v: %w", wrapperNode.Content[i].Line, err)
+				}
+
+				if err := distribution.prepare(baseDir); err != nil {
+					return nil, fmt.Errorf("invalid balance_distribution on line %v: %w", wrapperNode.Content[i].Line, err)
+				}
+
+				if distribution.Seed != nil {
+					logrus.Infof("validators list: using %v balance distribution with seed %d", distribution.Type, *distribution.Seed)
+				}
+			}
+		}
+	}
+
+	if listNode.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("invalid validators list on line %v: expected a list of validators", listNode.Line)
+	}
+
+	entries := make([]*listEntry, 0, len(listNode.Content))
+
+	for _, entryNode := range listNode.Content {
+		entry, err := parseListEntryNode(entryNode)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	validators, err := listEntriesToValidators(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, validator := range validators {
+		validator.Source = sourceName
+
+		if distribution != nil && entries[i].Balance == "" {
+			distribution.apply(validator, uint64(i), uint64(len(validators)))
+		}
+	}
+
+	return validators, nil
+}
+
+func parseListEntryNode(node *yaml.Node) (*listEntry, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid validator on line %v: expected an object", node.Line)
+	}
+
+	entry := &listEntry{
+		line:       node.Line,
+		fieldLines: map[string]int{},
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		field := keyNode.Value
+		entry.fieldLines[field] = keyNode.Line
+
+		if field == "metadata" {
+			if err := valueNode.Decode(&entry.Metadata); err != nil {
+				return nil, entry.fieldError(field, err)
+			}
+
+			continue
+		}
+
+		if valueNode.Kind != yaml.ScalarNode {
+			return nil, entry.fieldError(field, errors.New("expected a scalar value"))
+		}
+
+		if err := entry.setField(field, valueNode.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
+func (e *listEntry) setField(field, value string) error {
+	switch field {
+	case "pubkey":
+		e.PubKey = value
+	case "withdrawal_credentials":
+		e.WithdrawalCredentials = value
+	case "withdrawal_address":
+		e.WithdrawalAddress = value
+	case "withdrawal_type":
+		e.WithdrawalType = value
+	case "balance":
+		e.Balance = value
+	case "label":
+		e.Label = value
+	default:
+		return e.fieldError(field, errors.New("unknown field"))
+	}
+
+	return nil
+}
+
+// parseCSVValidatorsList parses a CSV list of validators with header.
+// Columns prefixed with "metadata." are added to the validator metadata.
+func parseCSVValidatorsList(data []byte) ([]*Validator, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	headerLine, _ := reader.FieldPos(0)
+
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+		if strings.HasPrefix(header[i], csvMetadataPrefix) {
+			continue
+		}
+
+		if err := (&listEntry{}).setField(header[i], ""); err != nil {
+			return nil, fmt.Errorf("invalid csv header on line %v: unknown column '%v'", headerLine, header[i])
+		}
+	}
+
+	entries := make([]*listEntry, 0)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		entry := &listEntry{
+			line:       line,
+			fieldLines: map[string]int{},
+		}
+
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+
+			if strings.HasPrefix(header[i], csvMetadataPrefix) {
+				if entry.Metadata == nil {
+					entry.Metadata = map[string]string{}
+				}
+
+				entry.Metadata[strings.TrimPrefix(header[i], csvMetadataPrefix)] = value
+
+				continue
+			}
+
+			if err := entry.setField(header[i], value); err != nil {
+				return nil, err
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return listEntriesToValidators(entries)
+}
+
+func listEntriesToValidators(entries []*listEntry) ([]*Validator, error) {
+	validators := make([]*Validator, 0, len(entries))
+	pubkeyMap := map[string]int{}
+
+	for _, entry := range entries {
+		validator, err := entry.toValidator()
+		if err != nil {
+			return nil, err
+		}
+
+		if pubkeyMap[string(validator.PublicKey[:])] != 0 {
+			return nil, fmt.Errorf("duplicate pubkey on line %v and %v", pubkeyMap[string(validator.PublicKey[:])], entry.line)
+		}
+
+		pubkeyMap[string(validator.PublicKey[:])] = entry.line
+
+		validators = append(validators, validator)
+	}
+
+	return validators, nil
+}
@@ -0,0 +1,236 @@
+package validators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func createTestListFile(t *testing.T, name, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("failed to write validators list: %v", err)
+	}
+
+	return path
+}
+
+func TestLoadValidatorsFromFile_YAML(t *testing.T) {
+	validatorsFile := createTestListFile(t, "validators.yaml", `
+- pubkey: 0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4
+  withdrawal_credentials: "0x001547805ff0547da9e51a7463a6a0c603eeda01dd930f7016185f0642b9ecaf"
+  balance: 32000000000
+  label: lighthouse-1
+  metadata:
+    owner: team-a
+- pubkey: "0xace5689384f87725790499fb5261b586d7dfb7d86058f0a909856272ba02df9929dcdb4b1ea529b02b948b3a1dca4d57"
+  withdrawal_address: "0x1234567890abcdef1234567890abcdef12345678"
+  withdrawal_type: compounding
+`)
+
+	validators, err := LoadValidatorsFromFile(validatorsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators: %v", err)
+	}
+
+	if len(validators) != 2 {
+		t.Fatalf("expected 2 validators, got %d", len(validators))
+	}
+
+	if validators[0].Balance == nil || *validators[0].Balance != 32000000000 {
+		t.Fatalf("expected validator 0 to have balance 32000000000, got %v", validators[0].Balance)
+	}
+
+	if validators[0].Label != "lighthouse-1" || validators[0].Metadata["owner"] != "team-a" {
+		t.Fatalf("unexpected label/metadata for validator 0: %v %v", validators[0].Label, validators[0].Metadata)
+	}
+
+	if got := validators[1].WithdrawalCredentials; got[0] != 0x02 || got[12] != 0x12 || got[31] != 0x78 {
+		t.Fatalf("unexpected withdrawal credentials for validator 1: 0x%x", got)
+	}
+
+	if validators[1].Balance != nil {
+		t.Fatalf("expected validator 1 to have no balance, got %d", *validators[1].Balance)
+	}
+}
+
+func TestLoadValidatorsFromFile_LegacyYAMLExtension(t *testing.T) {
+	validatorsFile := createTestListFile(t, "validators.yaml", `# legacy list with yaml extension
+0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4:0x001547805ff0547da9e51a7463a6a0c603eeda01dd930f7016185f0642b9ecaf:32000000000
+`)
+
+	validators, err := LoadValidatorsFromFile(validatorsFile)
+	if err != nil {
+		t.Fatalf("failed to load legacy validators list: %v", err)
+	}
+
+	if len(validators) != 1 || validators[0].Balance == nil || *validators[0].Balance != 32000000000 {
+		t.Fatalf("unexpected validators: %v", validators)
+	}
+}
+
+func TestLoadValidatorsFromFile_Name(t *testing.T) {
+	validatorsFile := createTestListFile(t, "validators.yaml", `
+name: prysm
+validators:
+  - pubkey: 0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4
+    withdrawal_credentials: "0x001547805ff0547da9e51a7463a6a0c603eeda01dd930f7016185f0642b9ecaf"
+`)
+
+	validators, err := LoadValidatorsFromFile(validatorsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators: %v", err)
+	}
+
+	if len(validators) != 1 || validators[0].Source != "prysm" {
+		t.Fatalf("expected 1 validator with source prysm, got %v", validators)
+	}
+}
+
+func TestLoadValidatorsFromFile_JSON(t *testing.T) {
+	validatorsFile := createTestListFile(t, "validators.json", `{
+  "validators": [
+    {
+      "pubkey": "0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4",
+      "withdrawal_address": "0x1234567890abcdef1234567890abcdef12345678",
+      "withdrawal_type": "eth1",
+      "balance": 64000000000
+    }
+  ]
+}`)
+
+	validators, err := LoadValidatorsFromFile(validatorsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators: %v", err)
+	}
+
+	if len(validators) != 1 || validators[0].WithdrawalCredentials[0] != 0x01 || *validators[0].Balance != 64000000000 {
+		t.Fatalf("unexpected validators: %v", validators)
+	}
+}
+
+func TestLoadValidatorsFromFile_CSV(t *testing.T) {
+	validatorsFile := createTestListFile(t, "validators.txt", `# generated list
+pubkey,withdrawal_credentials,withdrawal_address,withdrawal_type,balance,label,metadata.region
+0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4,0x001547805ff0547da9e51a7463a6a0c603eeda01dd930f7016185f0642b9ecaf,,,,teku-1,eu
+0xace5689384f87725790499fb5261b586d7dfb7d86058f0a909856272ba02df9929dcdb4b1ea529b02b948b3a1dca4d57,,0x1234567890abcdef1234567890abcdef12345678,0x02,2048000000000,teku-2,
+`)
+
+	validators, err := LoadValidatorsFromFile(validatorsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators: %v", err)
+	}
+
+	if len(validators) != 2 {
+		t.Fatalf("expected 2 validators, got %d", len(validators))
+	}
+
+	if validators[0].Label != "teku-1" || validators[0].Metadata["region"] != "eu" || validators[0].Balance != nil {
+		t.Fatalf("unexpected validator 0: %v %v %v", validators[0].Label, validators[0].Metadata, validators[0].Balance)
+	}
+
+	if validators[1].WithdrawalCredentials[0] != 0x02 || *validators[1].Balance != 2048000000000 || validators[1].Metadata != nil {
+		t.Fatalf("unexpected validator 1: 0x%x %v %v", validators[1].WithdrawalCredentials, *validators[1].Balance, validators[1].Metadata)
+	}
+}
+
+func TestLoadValidatorsFromFile_StructuredErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		data     string
+		expected string
+	}{
+		{
+			name: "unknown field",
+			file: "validators.yaml",
+			data: `
+- pubkey: 0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4
+  withdrawal_adress: "0x1234567890abcdef1234567890abcdef12345678"
+`,
+			expected: "invalid withdrawal_adress on line 3: unknown field",
+		},
+		{
+			name: "invalid balance",
+			file: "validators.yaml",
+			data: `
+- pubkey: 0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4
+  withdrawal_credentials: "0x001547805ff0547da9e51a7463a6a0c603eeda01dd930f7016185f0642b9ecaf"
+  balance: 32 ETH
+`,
+			expected: "invalid balance on line 4",
+		},
+		{
+			name: "address without type",
+			file: "validators.yaml",
+			data: `
+- pubkey: 0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4
+  withdrawal_address: "0x1234567890abcdef1234567890abcdef12345678"
+`,
+			expected: "invalid withdrawal_type on line 2: missing value",
+		},
+		{
+			name: "unknown withdrawal type",
+			file: "validators.yaml",
+			data: `
+- pubkey: 0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4
+  withdrawal_address: "0x1234567890abcdef1234567890abcdef12345678"
+  withdrawal_type: 0x03
+`,
+			expected: "invalid withdrawal_type on line 4: unknown withdrawal type",
+		},
+		{
+			name: "invalid credentials type",
+			file: "validators.yaml",
+			data: `
+- pubkey: 0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4
+  withdrawal_credentials: "0xff1547805ff0547da9e51a7463a6a0c603eeda01dd930f7016185f0642b9ecaf"
+`,
+			expected: "invalid withdrawal_credentials on line 3: invalid type",
+		},
+		{
+			name: "duplicate pubkey",
+			file: "validators.yaml",
+			data: `
+- pubkey: 0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4
+  withdrawal_credentials: "0x001547805ff0547da9e51a7463a6a0c603eeda01dd930f7016185f0642b9ecaf"
+- pubkey: 0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4
+  withdrawal_credentials: "0x001547805ff0547da9e51a7463a6a0c603eeda01dd930f7016185f0642b9ecaf"
+`,
+			expected: "duplicate pubkey on line 2 and 4",
+		},
+		{
+			name: "csv unknown column",
+			file: "validators.csv",
+			data: `pubkey,credentials
+0x9824e447621e4b3bca7794b91c664cc0b43322a70b1881b2f804e3a990a3965a64bfe7f098cb4c0396cd0c89218de0b4,0x001547805ff0547da9e51a7463a6a0c603eeda01dd930f7016185f0642b9ecaf
+`,
+			expected: "unknown column 'credentials'",
+		},
+		{
+			name: "csv invalid pubkey",
+			file: "validators.csv",
+			data: `pubkey,withdrawal_credentials
+
+0x9824e447621e4b3bca,0x001547805ff0547da9e51a7463a6a0c603eeda01dd930f7016185f0642b9ecaf
+`,
+			expected: "invalid pubkey on line 3: invalid length",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadValidatorsFromFile(createTestListFile(t, tt.file, tt.data))
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Fatalf("expected error to contain '%s', got %s", tt.expected, err)
+			}
+		})
+	}
+}
@@ -2,10 +2,8 @@ package validators
 
 import (
 	"crypto/sha256"
-	"encoding/hex"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 	"sync/atomic"
 
@@ -42,11 +40,28 @@ func GenerateValidatorsByMnemonic(mnemonicsConfigPath string) ([]*Validator, err
 
 		logrus.Infof("processing mnemonic %d, for %d validators", m, mnemonicSrc.Count)
 
-		seed, err := seedFromMnemonic(mnemonicSrc.Mnemonic)
+		seed, err := seedFromMnemonic(mnemonicSrc.Mnemonic, mnemonicSrc.Passphrase)
 		if err != nil {
 			return nil, fmt.Errorf("mnemonic %d is bad", m)
 		}
 
+		// withdrawal keys are derived from a separate mnemonic if configured
+		withdrawalSeed := seed
+
+		if mnemonicSrc.WithdrawalMnemonic != "" {
+			withdrawalSeed, err = seedFromMnemonic(mnemonicSrc.WithdrawalMnemonic, mnemonicSrc.WithdrawalPassphrase)
+			if err != nil {
+				return nil, fmt.Errorf("withdrawal mnemonic %d is bad", m)
+			}
+		} else if mnemonicSrc.WithdrawalPassphrase != "" {
+			return nil, fmt.Errorf("mnemonic %d has withdrawal_passphrase without withdrawal_mnemonic", m)
+		}
+
+		wdConfig, err := mnemonicSrc.getWithdrawalConfig(withdrawalSeed)
+		if err != nil {
+			return nil, fmt.Errorf("mnemonic %d has invalid withdrawal settings: %w", m, err)
+		}
+
 		for i := uint64(0); i < mnemonicSrc.Count; i++ {
 			valIndex := offset + i
 			idx := mnemonicSrc.Start + i
@@ -60,17 +75,20 @@ func GenerateValidatorsByMnemonic(mnemonicsConfigPath string) ([]*Validator, err
 				data := &Validator{
 					PublicKey:             phase0.BLSPubKey(signingSK.PublicKey().Marshal()),
 					WithdrawalCredentials: make([]byte, 32),
+					Source:                mnemonicSrc.Name,
+					KeyPath:               validatorKeyName(idx),
 				}
 
-				if mnemonicSrc.WdPrefix != "" && mnemonicSrc.WdPrefix != "0x00" && mnemonicSrc.WdAddress != "" {
+				if wdConfig.prefix != 0x00 {
 					// set withdrawal address (0x01 or 0x02 credentials)
-					address, err := hex.DecodeString(strings.ReplaceAll(mnemonicSrc.WdAddress, "0x", ""))
+					address, addressPath, err := wdConfig.getAddress(i, idx)
 					if err != nil {
-						return fmt.Errorf("failed to decode withdrawal address: %w", err)
+						return err
 					}
 
 					copy(data.WithdrawalCredentials[12:], address)
-					data.WithdrawalCredentials[0] = 0x01
+					data.WithdrawalCredentials[0] = wdConfig.prefix
+					data.WithdrawalKeyPath = addressPath
 				} else {
 					// set withdrawal BLS pubkey (0x00 credentials)
 					wdkeyPath := mnemonicSrc.WdKeyPath
@@ -78,7 +96,7 @@ func GenerateValidatorsByMnemonic(mnemonicsConfigPath string) ([]*Validator, err
 						wdkeyPath = withdrawalKeyName(idx)
 					}
 
-					withdrawSK, err := e2util.PrivateKeyFromSeedAndPath(seed, wdkeyPath)
+					withdrawSK, err := e2util.PrivateKeyFromSeedAndPath(withdrawalSeed, wdkeyPath)
 					if err != nil {
 						return err
 					}
@@ -88,19 +106,13 @@ func GenerateValidatorsByMnemonic(mnemonicsConfigPath string) ([]*Validator, err
 					h.Write(withdrawPub)
 					copy(data.WithdrawalCredentials, h.Sum(nil))
 					data.WithdrawalCredentials[0] = 0x00
-				}
-
-				if mnemonicSrc.WdPrefix != "" {
-					prefix, err := hex.DecodeString(strings.ReplaceAll(mnemonicSrc.WdPrefix, "0x", ""))
-					if err != nil {
-						return fmt.Errorf("failed to decode withdrawal prefix: %w", err)
-					}
-
-					copy(data.WithdrawalCredentials, prefix)
+					data.WithdrawalKeyPath = wdkeyPath
 				}
 
 				// Max effective balance by default for activation
-				if mnemonicSrc.Balance > 0 {
+				if mnemonicSrc.BalanceDistribution != nil {
+					mnemonicSrc.BalanceDistribution.apply(data, i, mnemonicSrc.Count)
+				} else if mnemonicSrc.Balance > 0 {
 					data.Balance = &mnemonicSrc.Balance
 				}
 
@@ -133,38 +145,75 @@ func withdrawalKeyName(i uint64) string {
 	return fmt.Sprintf("m/12381/3600/%d/0", i)
 }
 
-func seedFromMnemonic(mnemonic string) (seed []byte, err error) {
+func seedFromMnemonic(mnemonic, passphrase string) (seed []byte, err error) {
 	mnemonic = strings.TrimSpace(mnemonic)
 	if !bip39.IsMnemonicValid(mnemonic) {
 		return nil, errors.New("mnemonic is not valid")
 	}
 
-	return bip39.NewSeed(mnemonic, ""), nil
+	return bip39.NewSeed(mnemonic, passphrase), nil
 }
 
 type MnemonicSrc struct {
-	Mnemonic  string `yaml:"mnemonic"`
-	Start     uint64 `yaml:"start"`
-	Count     uint64 `yaml:"count"`
-	Balance   uint64 `yaml:"balance"`
-	WdAddress string `yaml:"wd_address"`
-	WdPrefix  string `yaml:"wd_prefix"`
-	WdKeyPath string `yaml:"wd_key_path"`
+	Name                     string               `yaml:"name,omitempty"`
+	Mnemonic                 string               `yaml:"mnemonic,omitempty"`
+	MnemonicEnv              string               `yaml:"mnemonic_env,omitempty"`
+	MnemonicFile             string               `yaml:"mnemonic_file,omitempty"`
+	Passphrase               string               `yaml:"passphrase,omitempty"`
+	PassphraseEnv            string               `yaml:"passphrase_env,omitempty"`
+	PassphraseFile           string               `yaml:"passphrase_file,omitempty"`
+	WithdrawalMnemonic       string               `yaml:"withdrawal_mnemonic,omitempty"`
+	WithdrawalMnemonicEnv    string               `yaml:"withdrawal_mnemonic_env,omitempty"`
+	WithdrawalMnemonicFile   string               `yaml:"withdrawal_mnemonic_file,omitempty"`
+	WithdrawalPassphrase     string               `yaml:"withdrawal_passphrase,omitempty"`
+	WithdrawalPassphraseEnv  string               `yaml:"withdrawal_passphrase_env,omitempty"`
+	WithdrawalPassphraseFile string               `yaml:"withdrawal_passphrase_file,omitempty"`
+	Start                    uint64               `yaml:"start,omitempty"`
+	Count                    uint64               `yaml:"count"`
+	Balance                  uint64               `yaml:"balance,omitempty"`
+	BalanceDistribution      *BalanceDistribution `yaml:"balance_distribution,omitempty"`
+	WdType                   string               `yaml:"wd_type,omitempty"`
+	WdAddress                string               `yaml:"wd_address,omitempty"`
+	WdAddresses              []string             `yaml:"wd_addresses,omitempty"`
+	WdAddressTemplate        string               `yaml:"wd_address_template,omitempty"`
+	WdAddressPath            string               `yaml:"wd_address_path,omitempty"`
+	WdPrefix                 string               `yaml:"wd_prefix,omitempty"`
+	WdKeyPath                string               `yaml:"wd_key_path,omitempty"`
 }
 
+// loadMnemonics loads the mnemonic sources from a (possibly encrypted) mnemonics file and resolves secret references.
 func loadMnemonics(srcPath string) ([]MnemonicSrc, error) {
-	f, err := os.Open(srcPath)
+	fileData, err := readMnemonicsFile(srcPath)
 	if err != nil {
 		return nil, err
 	}
 
-	defer f.Close()
-
 	var data []MnemonicSrc
 
-	dec := yaml.NewDecoder(f)
-	if err := dec.Decode(&data); err != nil {
-		return nil, err
+	if err := yaml.Unmarshal(fileData, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse mnemonics file: %w", sanitizeYamlError(err))
+	}
+
+	baseDir := mnemonicsBaseDir(srcPath)
+
+	for m := range data {
+		if err := data[m].resolveSecrets(baseDir); err != nil {
+			return nil, fmt.Errorf("mnemonic %d: %w", m, err)
+		}
+
+		if distribution := data[m].BalanceDistribution; distribution != nil {
+			if data[m].Balance > 0 {
+				return nil, fmt.Errorf("mnemonic %d: balance and balance_distribution cannot be combined", m)
+			}
+
+			if err := distribution.prepare(baseDir); err != nil {
+				return nil, fmt.Errorf("mnemonic %d: %w", m, err)
+			}
+
+			if distribution.Seed != nil {
+				logrus.Infof("mnemonic %d: using %v balance distribution with seed %d", m, distribution.Type, *distribution.Seed)
+			}
+		}
 	}
 
 	return data, nil
@@ -253,3 +253,277 @@ func TestGenerateValidatorsByMnemonic_MassKeys(t *testing.T) {
 		t.Fatalf("expected 200 validators, got %d", len(validators))
 	}
 }
+
+func TestGenerateValidatorsByMnemonic_WdType(t *testing.T) {
+	mnemonicsFile := createTestMnemonicsFile(t, `
+- mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  start: 0
+  count: 1
+  wd_type: bls
+- mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  start: 1
+  count: 2
+  wd_type: eth1
+  wd_addresses:
+    - "0x1111111111111111111111111111111111111111"
+    - "0x2222222222222222222222222222222222222222"
+- mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  start: 10
+  count: 2
+  wd_type: compounding
+  wd_address_template: "0xdead00000000000000000000000000000000{index}"
+`)
+
+	err := hbls.Init(hbls.BLS12_381)
+	if err != nil {
+		t.Fatalf("failed to initialize BLS12-381: %v", err)
+	}
+
+	validators, err := GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators from mnemonics: %v", err)
+	}
+
+	if len(validators) != 5 {
+		t.Fatalf("expected 5 validators, got %d", len(validators))
+	}
+
+	expectedCreds := []string{
+		"00844164a875d32ab3dd1388fb80f3376542726289c4d0a3d4270783b415b9d2",
+		"0100000000000000000000001111111111111111111111111111111111111111",
+		"0100000000000000000000002222222222222222222222222222222222222222",
+		"020000000000000000000000dead00000000000000000000000000000000000a",
+		"020000000000000000000000dead00000000000000000000000000000000000b",
+	}
+
+	for i, expected := range expectedCreds {
+		if value, _ := hex.DecodeString(expected); !bytes.Equal(validators[i].WithdrawalCredentials, value) {
+			t.Fatalf("expected validator %d to have withdrawal credentials 0x%s, got 0x%x", i, expected, validators[i].WithdrawalCredentials)
+		}
+	}
+}
+
+func TestGenerateValidatorsByMnemonic_InvalidWdSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		expected string
+	}{
+		{
+			name:     "unknown wd_type",
+			settings: "wd_type: execution2",
+			expected: "unknown withdrawal type",
+		},
+		{
+			name:     "unknown wd_prefix",
+			settings: "wd_prefix: \"0x03\"\n  wd_address: \"0x1234567890abcdef1234567890abcdef12345678\"",
+			expected: "unknown withdrawal prefix",
+		},
+		{
+			name:     "compounding without address",
+			settings: "wd_prefix: \"0x02\"",
+			expected: "withdrawal address required",
+		},
+		{
+			name:     "address without type",
+			settings: "wd_address: \"0x1234567890abcdef1234567890abcdef12345678\"",
+			expected: "wd_type is required",
+		},
+		{
+			name:     "bls with address",
+			settings: "wd_type: bls\n  wd_address: \"0x1234567890abcdef1234567890abcdef12345678\"",
+			expected: "withdrawal address cannot be used",
+		},
+		{
+			name:     "conflicting prefix",
+			settings: "wd_type: eth1\n  wd_prefix: \"0x02\"\n  wd_address: \"0x1234567890abcdef1234567890abcdef12345678\"",
+			expected: "conflicts with wd_type",
+		},
+		{
+			name:     "short address",
+			settings: "wd_type: eth1\n  wd_address: \"0x1234\"",
+			expected: "invalid length",
+		},
+		{
+			name:     "address list length",
+			settings: "wd_type: eth1\n  wd_addresses: [\"0x1234567890abcdef1234567890abcdef12345678\"]",
+			expected: "wd_addresses has 1 entries, expected 2",
+		},
+		{
+			name:     "template without placeholder",
+			settings: "wd_type: eth1\n  wd_address_template: \"0x1234567890abcdef1234567890abcdef12345678\"",
+			expected: "must contain {index} exactly once",
+		},
+		{
+			name:     "multiple address settings",
+			settings: "wd_type: eth1\n  wd_address: \"0x1234567890abcdef1234567890abcdef12345678\"\n  wd_address_template: \"0x1234567890abcdef1234567890abcdef1234{index}\"",
+			expected: "only one of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mnemonicsFile := createTestMnemonicsFile(t, `
+- mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  start: 0
+  count: 2
+  `+tt.settings+`
+`)
+
+			_, err := GenerateValidatorsByMnemonic(mnemonicsFile)
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Fatalf("expected error to contain '%s', got %s", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestGenerateValidatorsByMnemonic_WdAddressPath(t *testing.T) {
+	mnemonicsFile := createTestMnemonicsFile(t, `
+- mnemonic: "test test test test test test test test test test test junk"
+  start: 0
+  count: 2
+  wd_type: compounding
+  wd_address_path: "m/44'/60'/0'/0/{index}"
+`)
+
+	err := hbls.Init(hbls.BLS12_381)
+	if err != nil {
+		t.Fatalf("failed to initialize BLS12-381: %v", err)
+	}
+
+	validators, err := GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators from mnemonics: %v", err)
+	}
+
+	entries := GetWithdrawalAddresses(validators)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 withdrawal addresses, got %d", len(entries))
+	}
+
+	if entries[0].Address != "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266" || entries[0].Path != "m/44'/60'/0'/0/0" || entries[0].Type != "0x02" {
+		t.Fatalf("unexpected withdrawal address entry 0: %+v", entries[0])
+	}
+
+	if entries[1].Address != "0x70997970C51812dc3A010C7d01b50e0d17dc79C8" || entries[1].Index != 1 {
+		t.Fatalf("unexpected withdrawal address entry 1: %+v", entries[1])
+	}
+}
+
+func TestGenerateValidatorsByMnemonic_Passphrase(t *testing.T) {
+	mnemonicsFile := createTestMnemonicsFile(t, `
+- mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  count: 1
+  wd_type: bls
+- mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  passphrase: "signing secret"
+  count: 1
+  wd_type: bls
+- mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  withdrawal_mnemonic: "test test test test test test test test test test test junk"
+  start: 1
+  count: 1
+  wd_type: bls
+- mnemonic: "test test test test test test test test test test test junk"
+  count: 1
+  wd_type: bls
+`)
+
+	err := hbls.Init(hbls.BLS12_381)
+	if err != nil {
+		t.Fatalf("failed to initialize BLS12-381: %v", err)
+	}
+
+	validators, err := GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators from mnemonics: %v", err)
+	}
+
+	if len(validators) != 4 {
+		t.Fatalf("expected 4 validators, got %d", len(validators))
+	}
+
+	if validators[0].PublicKey == validators[1].PublicKey {
+		t.Fatalf("expected passphrase to change the validator pubkey")
+	}
+
+	if bytes.Equal(validators[0].WithdrawalCredentials, validators[1].WithdrawalCredentials) {
+		t.Fatalf("expected passphrase to change the withdrawal credentials")
+	}
+
+	// signing key derived from the main mnemonic, withdrawal key from the withdrawal mnemonic
+	if validators[2].PublicKey == validators[3].PublicKey {
+		t.Fatalf("expected signing key to be derived from the main mnemonic")
+	}
+
+	mnemonicsFile = createTestMnemonicsFile(t, `
+- mnemonic: "test test test test test test test test test test test junk"
+  start: 1
+  count: 1
+  wd_type: bls
+`)
+
+	expected, err := GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators from mnemonics: %v", err)
+	}
+
+	if !bytes.Equal(validators[2].WithdrawalCredentials, expected[0].WithdrawalCredentials) {
+		t.Fatalf("expected withdrawal credentials 0x%x, got 0x%x", expected[0].WithdrawalCredentials, validators[2].WithdrawalCredentials)
+	}
+}
+
+func TestGenerateValidatorsByMnemonic_InvalidWithdrawalMnemonic(t *testing.T) {
+	mnemonicsFile := createTestMnemonicsFile(t, `
+- mnemonic: "test test test test test test test test test test test junk"
+  withdrawal_mnemonic: "invalid mnemonic"
+  count: 1
+  wd_type: bls
+`)
+
+	_, err := GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err == nil || !strings.Contains(err.Error(), "withdrawal mnemonic 0 is bad") {
+		t.Fatalf("expected withdrawal mnemonic error, got %v", err)
+	}
+
+	mnemonicsFile = createTestMnemonicsFile(t, `
+- mnemonic: "test test test test test test test test test test test junk"
+  withdrawal_passphrase: "secret"
+  count: 1
+  wd_type: bls
+`)
+
+	_, err = GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err == nil || !strings.Contains(err.Error(), "withdrawal_passphrase without withdrawal_mnemonic") {
+		t.Fatalf("expected withdrawal passphrase error, got %v", err)
+	}
+}
+
+func TestGenerateValidatorsByMnemonic_Name(t *testing.T) {
+	mnemonicsFile := createTestMnemonicsFile(t, `
+- name: lighthouse
+  mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  start: 5
+  count: 1
+- mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  count: 1
+`)
+
+	validators, err := GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators from mnemonics: %v", err)
+	}
+
+	if validators[0].Source != "lighthouse" || validators[0].KeyPath != "m/12381/3600/5/0/0" {
+		t.Fatalf("unexpected source/path for validator 0: %v %v", validators[0].Source, validators[0].KeyPath)
+	}
+
+	if validators[1].Source != "" || validators[1].KeyPath != "m/12381/3600/0/0/0" {
+		t.Fatalf("unexpected source/path for validator 1: %v %v", validators[1].Source, validators[1].KeyPath)
+	}
+}
@@ -0,0 +1,80 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tyler-smith/go-bip39"
+	"gopkg.in/yaml.v3"
+)
+
+// mnemonicEntropyBits is the entropy size for generated mnemonics (24 words).
+const mnemonicEntropyBits = 256
+
+// NewMnemonic generates a cryptographically random 24 word BIP-39 mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropyBits)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate entropy: %w", err)
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// NewMnemonicSrcs generates mnemonic sources with fresh random mnemonics.
+// All other settings are copied from the template and validated for each source.
+// If withdrawalMnemonic is set, a separate random withdrawal mnemonic is generated for each source.
+func NewMnemonicSrcs(sources int, template *MnemonicSrc, withdrawalMnemonic bool) ([]MnemonicSrc, error) {
+	if sources <= 0 {
+		return nil, errors.New("number of sources must be greater than 0")
+	}
+
+	if template.Count == 0 {
+		return nil, errors.New("number of validators per source must be greater than 0")
+	}
+
+	mnemonicSrcs := make([]MnemonicSrc, sources)
+
+	for m := range mnemonicSrcs {
+		mnemonicSrc := *template
+
+		mnemonic, err := NewMnemonic()
+		if err != nil {
+			return nil, err
+		}
+
+		mnemonicSrc.Mnemonic = mnemonic
+
+		if withdrawalMnemonic {
+			mnemonicSrc.WithdrawalMnemonic, err = NewMnemonic()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		withdrawalSeed, err := seedFromMnemonic(mnemonicSrc.Mnemonic, mnemonicSrc.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("generated mnemonic %d is bad", m)
+		}
+
+		if mnemonicSrc.WithdrawalMnemonic != "" {
+			withdrawalSeed, err = seedFromMnemonic(mnemonicSrc.WithdrawalMnemonic, mnemonicSrc.WithdrawalPassphrase)
+			if err != nil {
+				return nil, fmt.Errorf("generated withdrawal mnemonic %d is bad", m)
+			}
+		}
+
+		if _, err := mnemonicSrc.getWithdrawalConfig(withdrawalSeed); err != nil {
+			return nil, fmt.Errorf("invalid withdrawal settings: %w", err)
+		}
+
+		mnemonicSrcs[m] = mnemonicSrc
+	}
+
+	return mnemonicSrcs, nil
+}
+
+// MarshalMnemonics serializes mnemonic sources to the mnemonics file format.
+func MarshalMnemonics(mnemonicSrcs []MnemonicSrc) ([]byte, error) {
+	return yaml.Marshal(mnemonicSrcs)
+}
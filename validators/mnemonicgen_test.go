@@ -0,0 +1,70 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	hbls "github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func TestNewMnemonicSrcs_RoundTrip(t *testing.T) {
+	mnemonicSrcs, err := NewMnemonicSrcs(2, &MnemonicSrc{
+		Count:     3,
+		Balance:   64000000000,
+		WdType:    "compounding",
+		WdAddress: "0x1234567890abcdef1234567890abcdef12345678",
+	}, true)
+	if err != nil {
+		t.Fatalf("failed to generate mnemonics: %v", err)
+	}
+
+	if len(mnemonicSrcs) != 2 {
+		t.Fatalf("expected 2 mnemonic sources, got %d", len(mnemonicSrcs))
+	}
+
+	if mnemonicSrcs[0].Mnemonic == mnemonicSrcs[1].Mnemonic || mnemonicSrcs[0].Mnemonic == mnemonicSrcs[0].WithdrawalMnemonic {
+		t.Fatalf("expected unique mnemonics")
+	}
+
+	if words := strings.Fields(mnemonicSrcs[0].Mnemonic); len(words) != 24 || !bip39.IsMnemonicValid(mnemonicSrcs[0].Mnemonic) {
+		t.Fatalf("expected a valid 24 word mnemonic, got %d words", len(words))
+	}
+
+	data, err := MarshalMnemonics(mnemonicSrcs)
+	if err != nil {
+		t.Fatalf("failed to serialize mnemonics: %v", err)
+	}
+
+	err = hbls.Init(hbls.BLS12_381)
+	if err != nil {
+		t.Fatalf("failed to initialize BLS12-381: %v", err)
+	}
+
+	validators, err := GenerateValidatorsByMnemonic(createTestMnemonicsFile(t, string(data)))
+	if err != nil {
+		t.Fatalf("failed to load validators from generated mnemonics: %v", err)
+	}
+
+	if len(validators) != 6 {
+		t.Fatalf("expected 6 validators, got %d", len(validators))
+	}
+
+	if validators[5].WithdrawalCredentials[0] != 0x02 || *validators[5].Balance != 64000000000 {
+		t.Fatalf("unexpected validator settings: credentials 0x%x, balance %v", validators[5].WithdrawalCredentials, *validators[5].Balance)
+	}
+}
+
+func TestNewMnemonicSrcs_Invalid(t *testing.T) {
+	if _, err := NewMnemonicSrcs(0, &MnemonicSrc{Count: 1}, false); err == nil || !strings.Contains(err.Error(), "number of sources") {
+		t.Fatalf("expected sources error, got %v", err)
+	}
+
+	if _, err := NewMnemonicSrcs(1, &MnemonicSrc{}, false); err == nil || !strings.Contains(err.Error(), "number of validators") {
+		t.Fatalf("expected count error, got %v", err)
+	}
+
+	if _, err := NewMnemonicSrcs(1, &MnemonicSrc{Count: 1, WdType: "eth1"}, false); err == nil || !strings.Contains(err.Error(), "invalid withdrawal settings") {
+		t.Fatalf("expected withdrawal settings error, got %v", err)
+	}
+}
@@ -0,0 +1,356 @@
+package validators
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+// MnemonicsPassphraseEnv is the environment variable holding the passphrase of an encrypted mnemonics file.
+const MnemonicsPassphraseEnv = "MNEMONICS_PASSPHRASE"
+
+// stdinPath is the file path used to read a mnemonics file or secret from stdin.
+const stdinPath = "-"
+
+// default scrypt parameters for encrypted mnemonics files (same as EIP-2335 keystores)
+const (
+	mnemonicsScryptN     = 262144
+	mnemonicsScryptR     = 8
+	mnemonicsScryptP     = 1
+	mnemonicsScryptDKLen = 32
+)
+
+var (
+	stdinReader io.Reader = os.Stdin
+	stdinData   []byte
+	stdinErr    error
+	stdinOnce   sync.Once
+
+	yamlValuePattern = regexp.MustCompile(" `[^`]*`")
+)
+
+// readStdin reads stdin once, so multiple references to stdin resolve to the same content.
+func readStdin() ([]byte, error) {
+	stdinOnce.Do(func() {
+		stdinData, stdinErr = io.ReadAll(stdinReader)
+	})
+
+	return stdinData, stdinErr
+}
+
+// EncryptedMnemonics is the JSON container of an encrypted mnemonics file (scrypt + AES-GCM).
+type EncryptedMnemonics struct {
+	Version int `json:"version"`
+	KDF     struct {
+		Function string `json:"function"`
+		Params   struct {
+			N     int    `json:"n"`
+			R     int    `json:"r"`
+			P     int    `json:"p"`
+			DKLen int    `json:"dklen"`
+			Salt  string `json:"salt"`
+		} `json:"params"`
+	} `json:"kdf"`
+	Cipher struct {
+		Function string `json:"function"`
+		Nonce    string `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// EncryptMnemonics encrypts the content of a mnemonics file with the given passphrase.
+func EncryptMnemonics(plaintext []byte, passphrase string) ([]byte, error) {
+	return encryptMnemonics(plaintext, passphrase, mnemonicsScryptN)
+}
+
+func encryptMnemonics(plaintext []byte, passphrase string, scryptN int) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("empty passphrase")
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, mnemonicsScryptR, mnemonicsScryptP, mnemonicsScryptDKLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	gcm, err := newMnemonicsCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	encrypted := &EncryptedMnemonics{
+		Version:    1,
+		Ciphertext: hex.EncodeToString(gcm.Seal(nil, nonce, plaintext, nil)),
+	}
+	encrypted.KDF.Function = "scrypt"
+	encrypted.KDF.Params.N = scryptN
+	encrypted.KDF.Params.R = mnemonicsScryptR
+	encrypted.KDF.Params.P = mnemonicsScryptP
+	encrypted.KDF.Params.DKLen = mnemonicsScryptDKLen
+	encrypted.KDF.Params.Salt = hex.EncodeToString(salt)
+	encrypted.Cipher.Function = "aes-256-gcm"
+	encrypted.Cipher.Nonce = hex.EncodeToString(nonce)
+
+	return json.MarshalIndent(encrypted, "", "  ")
+}
+
+func newMnemonicsCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// parseEncryptedMnemonics returns the encrypted mnemonics container if the data is an encrypted mnemonics file.
+func parseEncryptedMnemonics(data []byte) *EncryptedMnemonics {
+	trimmedData := bytes.TrimSpace(data)
+	if len(trimmedData) == 0 || trimmedData[0] != '{' {
+		return nil
+	}
+
+	encrypted := &EncryptedMnemonics{}
+	if err := json.Unmarshal(trimmedData, encrypted); err != nil || encrypted.Ciphertext == "" {
+		return nil
+	}
+
+	return encrypted
+}
+
+// Decrypt decrypts the mnemonics file content with the given passphrase.
+func (e *EncryptedMnemonics) Decrypt(passphrase string) ([]byte, error) {
+	if e.Version != 1 {
+		return nil, fmt.Errorf("unsupported encrypted mnemonics version %v", e.Version)
+	}
+
+	if e.KDF.Function != "scrypt" {
+		return nil, fmt.Errorf("unsupported kdf function %v", e.KDF.Function)
+	}
+
+	if e.Cipher.Function != "aes-256-gcm" {
+		return nil, fmt.Errorf("unsupported cipher function %v", e.Cipher.Function)
+	}
+
+	salt, err := hex.DecodeString(e.KDF.Params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kdf salt: %w", err)
+	}
+
+	nonce, err := hex.DecodeString(e.Cipher.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cipher nonce: %w", err)
+	}
+
+	ciphertext, err := hex.DecodeString(e.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, e.KDF.Params.N, e.KDF.Params.R, e.KDF.Params.P, e.KDF.Params.DKLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	gcm, err := newMnemonicsCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid cipher nonce (invalid length)")
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt mnemonics file (wrong passphrase?)")
+	}
+
+	return plaintext, nil
+}
+
+// GetMnemonicsPassphrase returns the passphrase for an encrypted mnemonics file.
+// The passphrase is read from the MNEMONICS_PASSPHRASE environment variable or prompted for if running in a terminal.
+func GetMnemonicsPassphrase(confirm bool) (string, error) {
+	if passphrase, ok := os.LookupEnv(MnemonicsPassphraseEnv); ok {
+		return passphrase, nil
+	}
+
+	stdinFd := int(os.Stdin.Fd()) //nolint:gosec // fd fits into int
+	if !term.IsTerminal(stdinFd) {
+		return "", fmt.Errorf("no passphrase provided, set %v or run in a terminal", MnemonicsPassphraseEnv)
+	}
+
+	passphrase, err := promptPassphrase(stdinFd, "Enter mnemonics passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	if confirm {
+		confirmation, err := promptPassphrase(stdinFd, "Confirm mnemonics passphrase: ")
+		if err != nil {
+			return "", err
+		}
+
+		if confirmation != passphrase {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+
+	return passphrase, nil
+}
+
+func promptPassphrase(fd int, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	passphrase, err := term.ReadPassword(fd)
+
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+
+	return string(passphrase), nil
+}
+
+// readMnemonicsFile reads a (possibly encrypted) mnemonics file, "-" reads from stdin.
+func readMnemonicsFile(srcPath string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if srcPath == stdinPath {
+		data, err = readStdin()
+	} else {
+		data, err = os.ReadFile(srcPath)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := parseEncryptedMnemonics(data)
+	if encrypted == nil {
+		return data, nil
+	}
+
+	passphrase, err := GetMnemonicsPassphrase(false)
+	if err != nil {
+		return nil, fmt.Errorf("mnemonics file is encrypted: %w", err)
+	}
+
+	return encrypted.Decrypt(passphrase)
+}
+
+// resolveSecret resolves a secret that is either given inline, via environment variable or via file ("-" for stdin).
+// The returned errors never contain the secret itself.
+func resolveSecret(field, value, envName, filePath, baseDir string) (string, error) {
+	setCount := 0
+
+	for _, ref := range []string{value, envName, filePath} {
+		if ref != "" {
+			setCount++
+		}
+	}
+
+	if setCount > 1 {
+		return "", fmt.Errorf("only one of %v, %v_env or %v_file may be set", field, field, field)
+	}
+
+	switch {
+	case envName != "":
+		secret, ok := os.LookupEnv(envName)
+		if !ok {
+			return "", fmt.Errorf("%v_env: environment variable %v is not set", field, envName)
+		}
+
+		return secret, nil
+	case filePath == stdinPath:
+		data, err := readStdin()
+		if err != nil {
+			return "", fmt.Errorf("%v_file: failed to read stdin: %w", field, err)
+		}
+
+		return trimTrailingNewline(string(data)), nil
+	case filePath != "":
+		data, err := os.ReadFile(resolvePath(baseDir, filePath))
+		if err != nil {
+			return "", fmt.Errorf("%v_file: failed to read file: %w", field, err)
+		}
+
+		return trimTrailingNewline(string(data)), nil
+	}
+
+	return value, nil
+}
+
+// trimTrailingNewline removes the line ending of a secret read from a file. Other whitespace is kept,
+// as it is significant for passphrases (mnemonics are normalized separately).
+func trimTrailingNewline(secret string) string {
+	secret = strings.TrimSuffix(secret, "\n")
+
+	return strings.TrimSuffix(secret, "\r")
+}
+
+// resolveSecrets resolves the secret references of a mnemonic source.
+func (src *MnemonicSrc) resolveSecrets(baseDir string) error {
+	var err error
+
+	if src.Mnemonic, err = resolveSecret("mnemonic", src.Mnemonic, src.MnemonicEnv, src.MnemonicFile, baseDir); err != nil {
+		return err
+	}
+
+	if src.Passphrase, err = resolveSecret("passphrase", src.Passphrase, src.PassphraseEnv, src.PassphraseFile, baseDir); err != nil {
+		return err
+	}
+
+	if src.WithdrawalMnemonic, err = resolveSecret("withdrawal_mnemonic", src.WithdrawalMnemonic, src.WithdrawalMnemonicEnv, src.WithdrawalMnemonicFile, baseDir); err != nil {
+		return err
+	}
+
+	if src.WithdrawalPassphrase, err = resolveSecret("withdrawal_passphrase", src.WithdrawalPassphrase, src.WithdrawalPassphraseEnv, src.WithdrawalPassphraseFile, baseDir); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// sanitizeYamlError removes quoted values from yaml errors, so no (partial) secrets end up in logs.
+func sanitizeYamlError(err error) error {
+	return errors.New(yamlValuePattern.ReplaceAllString(err.Error(), ""))
+}
+
+// mnemonicsBaseDir returns the directory relative secret file paths are resolved against.
+func mnemonicsBaseDir(srcPath string) string {
+	if srcPath == stdinPath {
+		return "."
+	}
+
+	return filepath.Dir(srcPath)
+}
@@ -0,0 +1,220 @@
+package validators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	hbls "github.com/herumi/bls-eth-go-binary/bls"
+)
+
+const testSecretMnemonic = "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+
+func setTestStdin(t *testing.T, data string) {
+	t.Helper()
+
+	stdinReader = strings.NewReader(data)
+	stdinOnce = sync.Once{}
+
+	t.Cleanup(func() {
+		stdinReader = os.Stdin
+		stdinOnce = sync.Once{}
+	})
+}
+
+func generateTestSecretValidator(t *testing.T, mnemonicsFile string) *Validator {
+	t.Helper()
+
+	err := hbls.Init(hbls.BLS12_381)
+	if err != nil {
+		t.Fatalf("failed to initialize BLS12-381: %v", err)
+	}
+
+	validators, err := GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators from mnemonics: %v", err)
+	}
+
+	if len(validators) != 1 {
+		t.Fatalf("expected 1 validator, got %d", len(validators))
+	}
+
+	return validators[0]
+}
+
+func TestGenerateValidatorsByMnemonic_SecretReferences(t *testing.T) {
+	expected := generateTestSecretValidator(t, createTestMnemonicsFile(t, `
+- mnemonic: "`+testSecretMnemonic+`"
+  count: 1
+  wd_type: bls
+`))
+
+	t.Setenv("TEST_VALIDATOR_MNEMONIC", testSecretMnemonic)
+
+	validator := generateTestSecretValidator(t, createTestMnemonicsFile(t, `
+- mnemonic_env: TEST_VALIDATOR_MNEMONIC
+  count: 1
+  wd_type: bls
+`))
+	if validator.PublicKey != expected.PublicKey {
+		t.Fatalf("unexpected pubkey from mnemonic_env: %v", validator.PublicKey.String())
+	}
+
+	mnemonicsFile := createTestMnemonicsFile(t, `
+- mnemonic_file: secret.txt
+  count: 1
+  wd_type: bls
+`)
+	if err := os.WriteFile(filepath.Join(filepath.Dir(mnemonicsFile), "secret.txt"), []byte(testSecretMnemonic+"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	validator = generateTestSecretValidator(t, mnemonicsFile)
+	if validator.PublicKey != expected.PublicKey {
+		t.Fatalf("unexpected pubkey from mnemonic_file: %v", validator.PublicKey.String())
+	}
+
+	setTestStdin(t, testSecretMnemonic+"\n")
+
+	validator = generateTestSecretValidator(t, createTestMnemonicsFile(t, `
+- mnemonic_file: "-"
+  count: 1
+  wd_type: bls
+`))
+	if validator.PublicKey != expected.PublicKey {
+		t.Fatalf("unexpected pubkey from stdin: %v", validator.PublicKey.String())
+	}
+}
+
+func TestGenerateValidatorsByMnemonic_PassphraseWhitespace(t *testing.T) {
+	const passphrase = " secret passphrase "
+
+	expected := generateTestSecretValidator(t, createTestMnemonicsFile(t, `
+- mnemonic: "`+testSecretMnemonic+`"
+  passphrase: "`+passphrase+`"
+  count: 1
+  wd_type: bls
+`))
+
+	trimmed := generateTestSecretValidator(t, createTestMnemonicsFile(t, `
+- mnemonic: "`+testSecretMnemonic+`"
+  passphrase: "`+strings.TrimSpace(passphrase)+`"
+  count: 1
+  wd_type: bls
+`))
+	if trimmed.PublicKey == expected.PublicKey {
+		t.Fatalf("expected whitespace in passphrase to change the keys")
+	}
+
+	t.Setenv("TEST_VALIDATOR_PASSPHRASE", passphrase)
+
+	validator := generateTestSecretValidator(t, createTestMnemonicsFile(t, `
+- mnemonic: "`+testSecretMnemonic+`"
+  passphrase_env: TEST_VALIDATOR_PASSPHRASE
+  count: 1
+  wd_type: bls
+`))
+	if validator.PublicKey != expected.PublicKey {
+		t.Fatalf("unexpected pubkey from passphrase_env: %v", validator.PublicKey.String())
+	}
+
+	mnemonicsFile := createTestMnemonicsFile(t, `
+- mnemonic: "`+testSecretMnemonic+`"
+  passphrase_file: passphrase.txt
+  count: 1
+  wd_type: bls
+`)
+	if err := os.WriteFile(filepath.Join(filepath.Dir(mnemonicsFile), "passphrase.txt"), []byte(passphrase+"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write passphrase file: %v", err)
+	}
+
+	validator = generateTestSecretValidator(t, mnemonicsFile)
+	if validator.PublicKey != expected.PublicKey {
+		t.Fatalf("unexpected pubkey from passphrase_file: %v", validator.PublicKey.String())
+	}
+}
+
+func TestGenerateValidatorsByMnemonic_InvalidSecretReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{
+			name:     "missing env",
+			config:   "- mnemonic_env: TEST_MISSING_MNEMONIC_ENV\n  count: 1\n",
+			expected: "environment variable TEST_MISSING_MNEMONIC_ENV is not set",
+		},
+		{
+			name:     "multiple references",
+			config:   "- mnemonic: \"" + testSecretMnemonic + "\"\n  mnemonic_env: TEST_MNEMONIC\n  count: 1\n",
+			expected: "only one of mnemonic, mnemonic_env or mnemonic_file may be set",
+		},
+		{
+			name:     "missing file",
+			config:   "- mnemonic_file: missing.txt\n  count: 1\n",
+			expected: "mnemonic_file: failed to read file",
+		},
+		{
+			name:     "yaml type error",
+			config:   "- mnemonic: \"" + testSecretMnemonic + "\"\n  count: \"" + testSecretMnemonic + "\"\n",
+			expected: "failed to parse mnemonics file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := GenerateValidatorsByMnemonic(createTestMnemonicsFile(t, test.config))
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Fatalf("expected error containing '%v', got %v", test.expected, err)
+			}
+
+			if strings.Contains(err.Error(), "rare") {
+				t.Fatalf("error contains the mnemonic: %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerateValidatorsByMnemonic_Encrypted(t *testing.T) {
+	plaintext := []byte(`
+- mnemonic: "` + testSecretMnemonic + `"
+  count: 1
+  wd_type: bls
+`)
+	expected := generateTestSecretValidator(t, createTestMnemonicsFile(t, string(plaintext)))
+
+	encrypted, err := encryptMnemonics(plaintext, "secret passphrase", 1024)
+	if err != nil {
+		t.Fatalf("failed to encrypt mnemonics: %v", err)
+	}
+
+	if strings.Contains(string(encrypted), "rare") {
+		t.Fatalf("encrypted mnemonics file contains the mnemonic")
+	}
+
+	mnemonicsFile := createTestMnemonicsFile(t, string(encrypted))
+
+	t.Setenv(MnemonicsPassphraseEnv, "secret passphrase")
+
+	validator := generateTestSecretValidator(t, mnemonicsFile)
+	if validator.PublicKey != expected.PublicKey {
+		t.Fatalf("unexpected pubkey from encrypted file: %v", validator.PublicKey.String())
+	}
+
+	setTestStdin(t, string(encrypted))
+
+	validator = generateTestSecretValidator(t, stdinPath)
+	if validator.PublicKey != expected.PublicKey {
+		t.Fatalf("unexpected pubkey from encrypted stdin: %v", validator.PublicKey.String())
+	}
+
+	t.Setenv(MnemonicsPassphraseEnv, "wrong passphrase")
+
+	_, err = GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err == nil || !strings.Contains(err.Error(), "wrong passphrase?") {
+		t.Fatalf("expected wrong passphrase error, got %v", err)
+	}
+}
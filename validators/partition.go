@@ -0,0 +1,287 @@
+package validators
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v3"
+
+	e2util "github.com/wealdtech/go-eth2-util"
+)
+
+// NodePartition assigns validators of a mnemonics file to a node.
+// Validators are either the next count unassigned validators of the named mnemonic source or explicit genesis index ranges.
+// Mnemonic validators are the first genesis validators, so the genesis index equals the position in the mnemonics file.
+type NodePartition struct {
+	Name   string            `yaml:"name"`
+	Source string            `yaml:"source"`
+	Count  uint64            `yaml:"count"`
+	Ranges []*ValidatorRange `yaml:"ranges"`
+}
+
+// KeystoreOptions are the encryption settings for generated keystores.
+type KeystoreOptions struct {
+	// Password for all keystores. A random password per keystore is generated if empty.
+	Password string
+	// Insecure uses a minimal KDF cost, which is only suitable for test networks.
+	Insecure bool
+}
+
+// partitionKey is a validator key assigned to a node.
+type partitionKey struct {
+	seed    []byte
+	account uint64
+}
+
+// GenerateNodeKeystores splits the validators of a mnemonics file across nodes as described in the partitions file
+// and writes the keystores and passwords of each node to <outputDir>/<node>/keys and <outputDir>/<node>/secrets.
+func GenerateNodeKeystores(mnemonicsPath, partitionsPath, outputDir string, options *KeystoreOptions) error {
+	if err := initHerumiBLS(); err != nil {
+		return fmt.Errorf("failed to initialize BLS: %w", err)
+	}
+
+	mnemonics, err := loadMnemonics(mnemonicsPath)
+	if err != nil {
+		return err
+	}
+
+	partitionsData, err := os.ReadFile(partitionsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read partitions file: %w", err)
+	}
+
+	var partitions []*NodePartition
+	if err := yaml.Unmarshal(partitionsData, &partitions); err != nil {
+		return fmt.Errorf("failed to parse partitions file: %w", err)
+	}
+
+	assignments, err := assignPartitions(mnemonics, partitions)
+	if err != nil {
+		return err
+	}
+
+	// resolve the seeds and account indexes of all genesis validators of the mnemonics file
+	keys := make([]partitionKey, 0)
+
+	for m := range mnemonics {
+		seed, err := seedFromMnemonic(mnemonics[m].Mnemonic, mnemonics[m].Passphrase)
+		if err != nil {
+			return fmt.Errorf("mnemonic %d is bad", m)
+		}
+
+		for i := uint64(0); i < mnemonics[m].Count; i++ {
+			keys = append(keys, partitionKey{
+				seed:    seed,
+				account: mnemonics[m].Start + i,
+			})
+		}
+	}
+
+	for _, partition := range partitions {
+		nodeDir := filepath.Join(outputDir, partition.Name)
+		if _, err := os.Stat(nodeDir); err == nil {
+			return fmt.Errorf("node %v: output directory %v already exists", partition.Name, nodeDir)
+		}
+	}
+
+	for p, partition := range partitions {
+		nodeDir := filepath.Join(outputDir, partition.Name)
+		if err := writeNodeKeystores(nodeDir, keys, assignments[p], options); err != nil {
+			return fmt.Errorf("node %v: %w", partition.Name, err)
+		}
+
+		logrus.Infof("wrote %d keystores for node %v to %v", len(assignments[p]), partition.Name, nodeDir)
+	}
+
+	return nil
+}
+
+// assignPartitions resolves the genesis validator indexes of each node and checks that no validator is assigned twice.
+func assignPartitions(mnemonics []MnemonicSrc, partitions []*NodePartition) ([][]uint64, error) {
+	var total uint64
+
+	sourceStart := make(map[string]uint64, len(mnemonics))
+	sourceEnd := make(map[string]uint64, len(mnemonics))
+
+	for _, mnemonicSrc := range mnemonics {
+		if mnemonicSrc.Name != "" {
+			if _, exists := sourceStart[mnemonicSrc.Name]; exists {
+				return nil, fmt.Errorf("duplicate mnemonic source name %v", mnemonicSrc.Name)
+			}
+
+			sourceStart[mnemonicSrc.Name] = total
+			sourceEnd[mnemonicSrc.Name] = total + mnemonicSrc.Count
+		}
+
+		total += mnemonicSrc.Count
+	}
+
+	if len(partitions) == 0 {
+		return nil, errors.New("no partitions defined")
+	}
+
+	// owners holds the partition index + 1 of each assigned validator
+	owners := make([]int, total)
+	nodeNames := make(map[string]bool, len(partitions))
+	sourceCursor := make(map[string]uint64, len(mnemonics))
+	assignments := make([][]uint64, len(partitions))
+
+	for p, partition := range partitions {
+		if err := checkNodeName(partition.Name); err != nil {
+			return nil, fmt.Errorf("partition %d: %w", p, err)
+		}
+
+		if nodeNames[partition.Name] {
+			return nil, fmt.Errorf("partition %d: duplicate node name %v", p, partition.Name)
+		}
+
+		nodeNames[partition.Name] = true
+
+		if partition.Count > 0 && len(partition.Ranges) > 0 {
+			return nil, fmt.Errorf("node %v: count and ranges cannot be combined", partition.Name)
+		}
+
+		if partition.Source != "" {
+			if _, exists := sourceStart[partition.Source]; !exists {
+				return nil, fmt.Errorf("node %v: unknown mnemonic source %v", partition.Name, partition.Source)
+			}
+		}
+
+		switch {
+		case partition.Count > 0:
+			if partition.Source == "" {
+				return nil, fmt.Errorf("node %v: count requires a source", partition.Name)
+			}
+
+			// take the next unassigned validators of the source
+			index := max(sourceStart[partition.Source], sourceCursor[partition.Source])
+			for ; index < sourceEnd[partition.Source] && uint64(len(assignments[p])) < partition.Count; index++ {
+				if owners[index] == 0 {
+					owners[index] = p + 1
+					assignments[p] = append(assignments[p], index)
+				}
+			}
+
+			if uint64(len(assignments[p])) < partition.Count {
+				return nil, fmt.Errorf("node %v: source %v has only %d unassigned validators left", partition.Name, partition.Source, len(assignments[p]))
+			}
+
+			sourceCursor[partition.Source] = index
+		case len(partition.Ranges) > 0:
+			for _, validatorRange := range partition.Ranges {
+				if validatorRange.Start >= validatorRange.End {
+					return nil, fmt.Errorf("node %v: invalid range %d-%d (end exclusive)", partition.Name, validatorRange.Start, validatorRange.End)
+				}
+
+				if validatorRange.End > total {
+					return nil, fmt.Errorf("node %v: range %d-%d exceeds the %d mnemonic validators", partition.Name, validatorRange.Start, validatorRange.End, total)
+				}
+
+				if partition.Source != "" && (validatorRange.Start < sourceStart[partition.Source] || validatorRange.End > sourceEnd[partition.Source]) {
+					return nil, fmt.Errorf("node %v: range %d-%d is not within source %v", partition.Name, validatorRange.Start, validatorRange.End, partition.Source)
+				}
+
+				for index := validatorRange.Start; index < validatorRange.End; index++ {
+					if owners[index] != 0 {
+						return nil, fmt.Errorf("node %v: validator %d is already assigned to node %v", partition.Name, index, partitions[owners[index]-1].Name)
+					}
+
+					owners[index] = p + 1
+					assignments[p] = append(assignments[p], index)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("node %v: count or ranges required", partition.Name)
+		}
+	}
+
+	return assignments, nil
+}
+
+func checkNodeName(name string) error {
+	if name == "" {
+		return errors.New("missing node name")
+	}
+
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid node name %v (must be usable as directory name)", name)
+	}
+
+	return nil
+}
+
+// writeNodeKeystores writes the keystores and passwords for the given validator indexes of a node.
+func writeNodeKeystores(nodeDir string, keys []partitionKey, indexes []uint64, options *KeystoreOptions) error {
+	keysDir := filepath.Join(nodeDir, "keys")
+	secretsDir := filepath.Join(nodeDir, "secrets")
+
+	for _, dir := range []string{keysDir, secretsDir} {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	var g errgroup.Group
+
+	g.SetLimit(runtime.NumCPU())
+
+	for _, index := range indexes {
+		key := keys[index]
+
+		g.Go(func() error {
+			path := validatorKeyName(key.account)
+
+			secretKey, err := e2util.PrivateKeyFromSeedAndPath(key.seed, path)
+			if err != nil {
+				return fmt.Errorf("failed to derive key %v: %w", path, err)
+			}
+
+			password := options.Password
+			if password == "" {
+				passwordBytes := make([]byte, 16)
+				if _, err := rand.Read(passwordBytes); err != nil {
+					return fmt.Errorf("failed to generate password: %w", err)
+				}
+
+				password = hex.EncodeToString(passwordBytes)
+			}
+
+			keystore, err := EncryptKeystore(secretKey.Marshal(), password, path, options.Insecure)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt keystore for validator %d: %w", index, err)
+			}
+
+			keystoreData, err := json.MarshalIndent(keystore, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to serialize keystore: %w", err)
+			}
+
+			pubkey := "0x" + keystore.PubKey
+
+			if err := os.MkdirAll(filepath.Join(keysDir, pubkey), 0o700); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+
+			if err := os.WriteFile(filepath.Join(keysDir, pubkey, "voting-keystore.json"), keystoreData, 0o600); err != nil {
+				return fmt.Errorf("failed to write keystore: %w", err)
+			}
+
+			if err := os.WriteFile(filepath.Join(secretsDir, pubkey), []byte(password), 0o600); err != nil {
+				return fmt.Errorf("failed to write password: %w", err)
+			}
+
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
@@ -0,0 +1,219 @@
+package validators
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testPartitionMnemonics = `
+- name: lighthouse
+  mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  count: 3
+  wd_type: eth1
+  wd_address: "0x1234567890abcdef1234567890abcdef12345678"
+- name: teku
+  mnemonic: "rare observe fox place unfold bargain cannon direct title sorry rabbit juice body autumn quality decrease mixture transfer crisp unveil path depend brick scissors"
+  start: 10
+  count: 2
+  wd_type: eth1
+  wd_address: "0x1234567890abcdef1234567890abcdef12345678"
+`
+
+func TestEncryptKeystore(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x01}, 32)
+
+	for _, insecure := range []bool{false, true} {
+		keystore, err := EncryptKeystore(secret, testKeystorePassword, "m/12381/3600/0/0/0", insecure)
+		if err != nil {
+			t.Fatalf("failed to encrypt keystore: %v", err)
+		}
+
+		decrypted, err := keystore.Decrypt(testKeystorePassword)
+		if err != nil {
+			t.Fatalf("failed to decrypt keystore: %v", err)
+		}
+
+		if !bytes.Equal(decrypted, secret) {
+			t.Fatalf("decrypted secret mismatch: 0x%x", decrypted)
+		}
+
+		if _, err := keystore.Decrypt("wrong"); err == nil {
+			t.Fatalf("expected error for wrong password")
+		}
+
+		if len(keystore.UUID) != 36 || keystore.UUID[14] != '4' || keystore.Version != 4 {
+			t.Fatalf("unexpected uuid/version: %v %v", keystore.UUID, keystore.Version)
+		}
+	}
+}
+
+func TestAssignPartitions(t *testing.T) {
+	mnemonics := []MnemonicSrc{{Name: "lighthouse", Count: 4}, {Name: "teku", Count: 4}}
+
+	assignments, err := assignPartitions(mnemonics, []*NodePartition{
+		{Name: "node-1", Source: "lighthouse", Count: 2},
+		{Name: "node-2", Source: "lighthouse", Count: 2},
+		{Name: "node-3", Ranges: []*ValidatorRange{{Start: 4, End: 5}, {Start: 7, End: 8}}},
+		{Name: "node-4", Source: "teku", Count: 2},
+	})
+	if err != nil {
+		t.Fatalf("failed to assign partitions: %v", err)
+	}
+
+	// node-4 skips the validators of the teku source already assigned to node-3
+	expected := [][]uint64{{0, 1}, {2, 3}, {4, 7}, {5, 6}}
+	for p := range expected {
+		if len(assignments[p]) != len(expected[p]) {
+			t.Fatalf("unexpected assignment for partition %d: %v", p, assignments[p])
+		}
+
+		for i := range expected[p] {
+			if assignments[p][i] != expected[p][i] {
+				t.Fatalf("unexpected assignment for partition %d: %v", p, assignments[p])
+			}
+		}
+	}
+}
+
+func TestAssignPartitionsErrors(t *testing.T) {
+	mnemonics := []MnemonicSrc{{Name: "lighthouse", Count: 4}, {Name: "teku", Count: 4}}
+
+	tests := []struct {
+		name       string
+		partitions []*NodePartition
+		err        string
+	}{
+		{
+			name: "overlapping ranges",
+			partitions: []*NodePartition{
+				{Name: "node-1", Ranges: []*ValidatorRange{{Start: 0, End: 3}}},
+				{Name: "node-2", Ranges: []*ValidatorRange{{Start: 1, End: 2}}},
+			},
+			err: "node node-2: validator 1 is already assigned to node node-1",
+		},
+		{
+			name: "range after count",
+			partitions: []*NodePartition{
+				{Name: "node-1", Source: "lighthouse", Count: 3},
+				{Name: "node-2", Ranges: []*ValidatorRange{{Start: 2, End: 4}}},
+			},
+			err: "node node-2: validator 2 is already assigned to node node-1",
+		},
+		{
+			name:       "exceeds source",
+			partitions: []*NodePartition{{Name: "node-1", Source: "teku", Count: 5}},
+			err:        "source teku has only 4 unassigned validators left",
+		},
+		{
+			name:       "exceeds validators",
+			partitions: []*NodePartition{{Name: "node-1", Ranges: []*ValidatorRange{{Start: 6, End: 9}}}},
+			err:        "range 6-9 exceeds the 8 mnemonic validators",
+		},
+		{
+			name:       "range outside source",
+			partitions: []*NodePartition{{Name: "node-1", Source: "lighthouse", Ranges: []*ValidatorRange{{Start: 3, End: 5}}}},
+			err:        "range 3-5 is not within source lighthouse",
+		},
+		{
+			name:       "unknown source",
+			partitions: []*NodePartition{{Name: "node-1", Source: "prysm", Count: 1}},
+			err:        "unknown mnemonic source prysm",
+		},
+		{
+			name: "duplicate node",
+			partitions: []*NodePartition{
+				{Name: "node-1", Source: "lighthouse", Count: 1},
+				{Name: "node-1", Source: "teku", Count: 1},
+			},
+			err: "duplicate node name node-1",
+		},
+		{
+			name:       "invalid node name",
+			partitions: []*NodePartition{{Name: "../node-1", Source: "lighthouse", Count: 1}},
+			err:        "invalid node name",
+		},
+		{
+			name:       "no validators",
+			partitions: []*NodePartition{{Name: "node-1", Source: "lighthouse"}},
+			err:        "count or ranges required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := assignPartitions(mnemonics, test.partitions)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestGenerateNodeKeystores(t *testing.T) {
+	dir := t.TempDir()
+	mnemonicsFile := createTestMnemonicsFile(t, testPartitionMnemonics)
+	partitionsFile := filepath.Join(dir, "nodes.yaml")
+	outputDir := filepath.Join(dir, "output")
+
+	err := os.WriteFile(partitionsFile, []byte(`
+- name: node-1
+  source: lighthouse
+  count: 2
+- name: node-2
+  ranges:
+    - { start: 2, end: 4 }
+`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write partitions file: %v", err)
+	}
+
+	if err := GenerateNodeKeystores(mnemonicsFile, partitionsFile, outputDir, &KeystoreOptions{Insecure: true}); err != nil {
+		t.Fatalf("failed to generate node keystores: %v", err)
+	}
+
+	mnemonicValidators, err := GenerateValidatorsByMnemonic(mnemonicsFile)
+	if err != nil {
+		t.Fatalf("failed to load validators from mnemonics: %v", err)
+	}
+
+	// the generated keystores must load back with the pubkeys of the assigned genesis validators
+	for node, indexes := range map[string][]int{"node-1": {0, 1}, "node-2": {2, 3}} {
+		keystoresFile := filepath.Join(dir, node+".yaml")
+		config := "- dir: output/" + node + "/keys\n  secrets_dir: output/" + node + "/secrets\n  defaults:\n    withdrawal_credentials: \"0x01000000000000000000000000000000000000000000000000000000000000ff\"\n"
+
+		if err := os.WriteFile(keystoresFile, []byte(config), 0o600); err != nil {
+			t.Fatalf("failed to write keystores config: %v", err)
+		}
+
+		nodeValidators, err := LoadValidatorsFromKeystores(keystoresFile)
+		if err != nil {
+			t.Fatalf("failed to load keystores of %v: %v", node, err)
+		}
+
+		if len(nodeValidators) != len(indexes) {
+			t.Fatalf("expected %d keystores for %v, got %d", len(indexes), node, len(nodeValidators))
+		}
+
+		for _, index := range indexes {
+			found := false
+
+			for _, validator := range nodeValidators {
+				if validator.PublicKey == mnemonicValidators[index].PublicKey {
+					found = true
+				}
+			}
+
+			if !found {
+				t.Fatalf("missing keystore for validator %d in %v", index, node)
+			}
+		}
+	}
+
+	err = GenerateNodeKeystores(mnemonicsFile, partitionsFile, outputDir, &KeystoreOptions{Insecure: true})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected error for existing output directory, got %v", err)
+	}
+}
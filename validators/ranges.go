@@ -0,0 +1,147 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// ValidatorRange is a contiguous range of genesis validator indexes (end exclusive).
+type ValidatorRange struct {
+	Start uint64 `yaml:"start"`
+	End   uint64 `yaml:"end"`
+}
+
+// SourceRanges describes the genesis validators of a named validator source.
+type SourceRanges struct {
+	Name       string  `yaml:"name"`
+	Count      uint64  `yaml:"count"`
+	Balance    uint64  `yaml:"balance"`
+	StakeShare float64 `yaml:"stake_share"`
+	// NonSigningCount is the number of validators without known secret keys (e.g. synthetic validators).
+	NonSigningCount uint64            `yaml:"non_signing_count,omitempty"`
+	Ranges          []*ValidatorRange `yaml:"ranges"`
+}
+
+// ValidatorRanges maps the named validator sources to the genesis validator index ranges they own.
+type ValidatorRanges struct {
+	TotalCount           uint64          `yaml:"total_count"`
+	TotalBalance         uint64          `yaml:"total_balance"`
+	TotalNonSigningCount uint64          `yaml:"total_non_signing_count,omitempty"`
+	Sources              []*SourceRanges `yaml:"sources"`
+}
+
+// GetValidatorRanges groups the validators by source name. Indexes refer to the position in the given validator list,
+// sources are ordered by their first validator. Validators without balance count with the default balance.
+func GetValidatorRanges(validators []*Validator, defaultBalance uint64) *ValidatorRanges {
+	ranges := &ValidatorRanges{
+		Sources: []*SourceRanges{},
+	}
+	sourceMap := map[string]*SourceRanges{}
+
+	for index, validator := range validators {
+		source := sourceMap[validator.Source]
+		if source == nil {
+			source = &SourceRanges{
+				Name: validator.Source,
+			}
+			sourceMap[validator.Source] = source
+			ranges.Sources = append(ranges.Sources, source)
+		}
+
+		balance := defaultBalance
+		if validator.Balance != nil {
+			balance = *validator.Balance
+		}
+
+		if len(source.Ranges) > 0 && source.Ranges[len(source.Ranges)-1].End == uint64(index) {
+			source.Ranges[len(source.Ranges)-1].End++
+		} else {
+			source.Ranges = append(source.Ranges, &ValidatorRange{
+				Start: uint64(index),
+				End:   uint64(index) + 1,
+			})
+		}
+
+		source.Count++
+		source.Balance += balance
+		ranges.TotalCount++
+		ranges.TotalBalance += balance
+
+		if validator.NonSigning {
+			source.NonSigningCount++
+			ranges.TotalNonSigningCount++
+		}
+	}
+
+	if ranges.TotalBalance > 0 {
+		for _, source := range ranges.Sources {
+			// round to 6 decimals to keep the output readable
+			share := float64(source.Balance) / float64(ranges.TotalBalance)
+			source.StakeShare = math.Round(share*1_000_000) / 1_000_000
+		}
+	}
+
+	return ranges
+}
+
+// MarshalValidatorsCSV serializes the validators as CSV with index, pubkey, source, signing key derivation path, label
+// and the non-signing flag. The metadata of the validators is added as one column per metadata key (prefixed with "metadata.", like in
+// CSV validators lists).
+func MarshalValidatorsCSV(validators []*Validator) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writer := csv.NewWriter(&buf)
+
+	metadataKeys := []string{}
+	metadataKeyMap := map[string]bool{}
+
+	for _, validator := range validators {
+		for key := range validator.Metadata {
+			if !metadataKeyMap[key] {
+				metadataKeyMap[key] = true
+				metadataKeys = append(metadataKeys, key)
+			}
+		}
+	}
+
+	slices.Sort(metadataKeys)
+
+	header := []string{"index", "pubkey", "source", "path", "label", "non_signing"}
+	for _, key := range metadataKeys {
+		header = append(header, csvMetadataPrefix+key)
+	}
+
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for index, validator := range validators {
+		record := []string{
+			strconv.Itoa(index),
+			validator.PublicKey.String(),
+			validator.Source,
+			validator.KeyPath,
+			validator.Label,
+			strconv.FormatBool(validator.NonSigning),
+		}
+
+		for _, key := range metadataKeys {
+			record = append(record, validator.Metadata[key])
+		}
+
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
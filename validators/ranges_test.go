@@ -0,0 +1,79 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetValidatorRanges(t *testing.T) {
+	balance := uint64(64_000_000_000)
+	validators := []*Validator{
+		{Source: "lighthouse"},
+		{Source: "lighthouse"},
+		{Source: "teku", Balance: &balance},
+		{Source: "lighthouse"},
+		{Source: "synthetic", NonSigning: true},
+	}
+
+	ranges := GetValidatorRanges(validators, 32_000_000_000)
+
+	if ranges.TotalCount != 5 || ranges.TotalBalance != 192_000_000_000 || ranges.TotalNonSigningCount != 1 {
+		t.Fatalf("unexpected totals: %d validators, %d gwei", ranges.TotalCount, ranges.TotalBalance)
+	}
+
+	if len(ranges.Sources) != 3 || ranges.Sources[0].Name != "lighthouse" || ranges.Sources[1].Name != "teku" {
+		t.Fatalf("unexpected sources: %v", ranges.Sources)
+	}
+
+	lighthouse := ranges.Sources[0]
+	if lighthouse.Count != 3 || lighthouse.StakeShare != 0.5 || lighthouse.NonSigningCount != 0 {
+		t.Fatalf("unexpected lighthouse count/share: %d %v", lighthouse.Count, lighthouse.StakeShare)
+	}
+
+	if len(lighthouse.Ranges) != 2 || *lighthouse.Ranges[0] != (ValidatorRange{0, 2}) || *lighthouse.Ranges[1] != (ValidatorRange{3, 4}) {
+		t.Fatalf("unexpected lighthouse ranges: %v %v", lighthouse.Ranges[0], lighthouse.Ranges[1])
+	}
+
+	if teku := ranges.Sources[1]; teku.StakeShare != 0.333333 || len(teku.Ranges) != 1 || *teku.Ranges[0] != (ValidatorRange{2, 3}) {
+		t.Fatalf("unexpected teku ranges: %v %v", teku.StakeShare, teku.Ranges)
+	}
+
+	if synthetic := ranges.Sources[2]; synthetic.NonSigningCount != 1 || *synthetic.Ranges[0] != (ValidatorRange{4, 5}) {
+		t.Fatalf("unexpected synthetic ranges: %v %v", synthetic.NonSigningCount, synthetic.Ranges)
+	}
+}
+
+func TestMarshalValidatorsCSV(t *testing.T) {
+	validators := []*Validator{
+		{Source: "lighthouse", KeyPath: "m/12381/3600/0/0/0", Label: "lighthouse-1", Metadata: map[string]string{"owner": "ef", "region": "eu"}},
+		{Source: "deposit-data deposits.json", Metadata: map[string]string{"owner": "ethpandaops"}},
+		{Source: "synthetic", Label: SyntheticValidatorLabel, NonSigning: true},
+	}
+	validators[1].PublicKey[0] = 0xab
+
+	csvData, err := MarshalValidatorsCSV(validators)
+	if err != nil {
+		t.Fatalf("failed to marshal validators csv: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(csvData)), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d", len(lines))
+	}
+
+	if lines[0] != "index,pubkey,source,path,label,non_signing,metadata.owner,metadata.region" {
+		t.Fatalf("unexpected header: %v", lines[0])
+	}
+
+	if !strings.HasPrefix(lines[1], "0,0x0000") || !strings.HasSuffix(lines[1], ",lighthouse,m/12381/3600/0/0/0,lighthouse-1,false,ef,eu") {
+		t.Fatalf("unexpected line 1: %v", lines[1])
+	}
+
+	if !strings.HasPrefix(lines[2], "1,0xab00") || !strings.HasSuffix(lines[2], ",deposit-data deposits.json,,,false,ethpandaops,") {
+		t.Fatalf("unexpected line 2: %v", lines[2])
+	}
+
+	if !strings.HasSuffix(lines[3], ",synthetic,,synthetic (non-signing),true,,") {
+		t.Fatalf("unexpected line 3: %v", lines[3])
+	}
+}
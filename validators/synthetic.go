@@ -0,0 +1,150 @@
+package validators
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	hbls "github.com/herumi/bls-eth-go-binary/bls"
+	bls12381 "github.com/kilic/bls12-381"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// SyntheticValidatorLabel is the label of synthetic validators.
+// Synthetic validators have valid public keys without known secret keys, so they can never sign.
+const SyntheticValidatorLabel = "synthetic (non-signing)"
+
+// syntheticChunkSize is the number of public keys derived by incremental point addition per worker.
+const syntheticChunkSize = 16384
+
+var hblsInitOnce sync.Once
+
+// initHerumiBLS initializes the herumi BLS library in ETH mode (once).
+func initHerumiBLS() error {
+	var err error
+
+	hblsInitOnce.Do(func() {
+		if err = hbls.Init(hbls.BLS12_381); err != nil {
+			return
+		}
+
+		err = hbls.SetETHmode(hbls.EthModeLatest)
+	})
+
+	return err
+}
+
+// syntheticBaseDST is the domain separation tag for hashing the seed to the base point of the synthetic public keys.
+const syntheticBaseDST = "ETH-BEACON-GENESIS-SYNTHETIC-VALIDATORS_BLS12381G1_XMD:SHA-256_SSWU_RO_"
+
+// syntheticBasePoint returns the public key of the first synthetic validator for a seed.
+// The point is derived by hashing the seed to G1 (RFC 9380), so its discrete log (the secret key) is unknown.
+func syntheticBasePoint(seed uint64) ([]byte, error) {
+	seedBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(seedBytes, seed)
+
+	g1 := bls12381.NewG1()
+
+	point, err := g1.HashToCurve(seedBytes, []byte(syntheticBaseDST))
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash seed to curve: %w", err)
+	}
+
+	return g1.ToCompressed(point), nil
+}
+
+// GenerateSyntheticValidators generates count validators with synthetic public keys for scale testing.
+// The public key of validator i is H(seed) + i * G1, with H(seed) the seed hashed to G1, so the keys are valid,
+// distinct and deterministic. Instead of deriving a key for each validator, the public keys are computed by
+// incremental point addition, which is orders of magnitude faster than the EIP-2333 derivation of mnemonic sources.
+// As the discrete log of H(seed) is unknown, nobody can compute the secret keys, so synthetic validators cannot sign
+// (they are labeled accordingly).
+// If withdrawalAddress is set, 0x01 credentials to that address are used, otherwise 0x00 credentials of the public key.
+func GenerateSyntheticValidators(seed, count uint64, withdrawalAddress []byte) ([]*Validator, error) {
+	if err := initHerumiBLS(); err != nil {
+		return nil, fmt.Errorf("failed to initialize BLS: %w", err)
+	}
+
+	if withdrawalAddress != nil && len(withdrawalAddress) != 20 {
+		return nil, fmt.Errorf("invalid withdrawal address (invalid length)")
+	}
+
+	logrus.Warnf("generating %d synthetic validators, which have no secret keys and can never sign", count)
+
+	oneKey := &hbls.SecretKey{}
+	if err := oneKey.SetDecString("1"); err != nil {
+		return nil, err
+	}
+
+	generator := oneKey.GetPublicKey()
+
+	basePoint, err := syntheticBasePoint(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	// allocate the validators and credentials in bulk, as this is the bottleneck for millions of validators
+	validatorData := make([]Validator, count)
+	credentialsData := make([]byte, count*32)
+	validators := make([]*Validator, count)
+
+	var g errgroup.Group
+
+	g.SetLimit(runtime.NumCPU())
+
+	for chunkStart := uint64(0); chunkStart < count; chunkStart += syntheticChunkSize {
+		chunkEnd := min(chunkStart+syntheticChunkSize, count)
+
+		g.Go(func() error {
+			pubKey := &hbls.PublicKey{}
+			if err := pubKey.Deserialize(basePoint); err != nil {
+				return fmt.Errorf("invalid synthetic base point: %w", err)
+			}
+
+			if chunkStart > 0 {
+				offsetKey := &hbls.SecretKey{}
+				if err := offsetKey.SetDecString(strconv.FormatUint(chunkStart, 10)); err != nil {
+					return fmt.Errorf("invalid synthetic offset: %w", err)
+				}
+
+				pubKey.Add(offsetKey.GetPublicKey())
+			}
+
+			for i := chunkStart; i < chunkEnd; i++ {
+				if i > chunkStart {
+					pubKey.Add(generator)
+				}
+
+				validator := &validatorData[i]
+				validator.PublicKey = phase0.BLSPubKey(pubKey.Serialize())
+				validator.WithdrawalCredentials = credentialsData[i*32 : (i+1)*32 : (i+1)*32]
+				validator.Label = SyntheticValidatorLabel
+				validator.NonSigning = true
+
+				if withdrawalAddress != nil {
+					validator.WithdrawalCredentials[0] = 0x01
+					copy(validator.WithdrawalCredentials[12:], withdrawalAddress)
+				} else {
+					credentials := sha256.Sum256(validator.PublicKey[:])
+					copy(validator.WithdrawalCredentials, credentials[:])
+					validator.WithdrawalCredentials[0] = 0x00
+				}
+
+				validators[i] = validator
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return validators, nil
+}
@@ -0,0 +1,129 @@
+package validators
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	bls12381 "github.com/kilic/bls12-381"
+	blsu "github.com/protolambda/bls12-381-util"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+func TestGenerateSyntheticValidators(t *testing.T) {
+	count := uint64(syntheticChunkSize + 10)
+
+	validators, err := GenerateSyntheticValidators(7, count, nil)
+	if err != nil {
+		t.Fatalf("failed to generate synthetic validators: %v", err)
+	}
+
+	if uint64(len(validators)) != count {
+		t.Fatalf("expected %d validators, got %d", count, len(validators))
+	}
+
+	pubkeys := map[[48]byte]bool{}
+
+	for i, validator := range validators {
+		if pubkeys[validator.PublicKey] {
+			t.Fatalf("duplicate synthetic pubkey at index %d", i)
+		}
+
+		pubkeys[validator.PublicKey] = true
+
+		if validator.Label != SyntheticValidatorLabel || !validator.NonSigning || validator.WithdrawalCredentials[0] != 0x00 {
+			t.Fatalf("unexpected synthetic validator %d: label %v, credentials 0x%x", i, validator.Label, validator.WithdrawalCredentials)
+		}
+	}
+
+	// the first key is the seed hashed to G1, checked against the independent hash to curve of blst
+	expectedBase := blst.HashToG1([]byte{7, 0, 0, 0, 0, 0, 0, 0}, []byte(syntheticBaseDST)).ToAffine().Compress()
+	if !bytes.Equal(validators[0].PublicKey[:], expectedBase) {
+		t.Fatalf("unexpected synthetic base pubkey 0x%x, expected 0x%x", validators[0].PublicKey, expectedBase)
+	}
+
+	// check the keys across the chunk boundary against base + i * G1
+	g1 := bls12381.NewG1()
+
+	basePoint, err := g1.FromCompressed(expectedBase)
+	if err != nil {
+		t.Fatalf("failed to decode base point: %v", err)
+	}
+
+	for _, i := range []uint64{1, syntheticChunkSize - 1, syntheticChunkSize, count - 1} {
+		expected := g1.New()
+		g1.MulScalarBig(expected, g1.One(), new(big.Int).SetUint64(i))
+		g1.Add(expected, expected, basePoint)
+
+		if !bytes.Equal(g1.ToCompressed(expected), validators[i].PublicKey[:]) {
+			t.Fatalf("unexpected synthetic pubkey at index %d", i)
+		}
+
+		// the pubkey must be a valid G1 point in the correct subgroup
+		var decodedKey blsu.Pubkey
+		if err := decodedKey.Deserialize((*[48]byte)(validators[i].PublicKey[:])); err != nil {
+			t.Fatalf("invalid synthetic pubkey at index %d: %v", i, err)
+		}
+	}
+
+	sameValidators, err := GenerateSyntheticValidators(7, 2, []byte{0x12, 0x34, 0x56, 0x78, 0x90, 0xab, 0xcd, 0xef, 0x12, 0x34, 0x56, 0x78, 0x90, 0xab, 0xcd, 0xef, 0x12, 0x34, 0x56, 0x78})
+	if err != nil {
+		t.Fatalf("failed to generate synthetic validators: %v", err)
+	}
+
+	if sameValidators[1].PublicKey != validators[1].PublicKey || sameValidators[1].WithdrawalCredentials[0] != 0x01 {
+		t.Fatalf("expected deterministic pubkeys and 0x01 credentials")
+	}
+
+	otherValidators, err := GenerateSyntheticValidators(8, 1, nil)
+	if err != nil {
+		t.Fatalf("failed to generate synthetic validators: %v", err)
+	}
+
+	if otherValidators[0].PublicKey == validators[0].PublicKey {
+		t.Fatalf("expected different pubkeys for different seeds")
+	}
+}
+
+func BenchmarkGenerateSyntheticValidators(b *testing.B) {
+	const count = 100_000
+
+	for i := 0; i < b.N; i++ {
+		if _, err := GenerateSyntheticValidators(uint64(i), count, nil); err != nil {
+			b.Fatalf("failed to generate synthetic validators: %v", err)
+		}
+	}
+
+	b.ReportMetric(float64(count*b.N)/b.Elapsed().Seconds(), "validators/s")
+}
+
+func BenchmarkGenerateValidatorsByMnemonic(b *testing.B) {
+	const count = 1_000
+
+	mnemonicsFile := b.TempDir() + "/mnemonics.yaml"
+	if err := writeBenchmarkMnemonicsFile(mnemonicsFile, count); err != nil {
+		b.Fatalf("failed to write mnemonics file: %v", err)
+	}
+
+	if err := initHerumiBLS(); err != nil {
+		b.Fatalf("failed to initialize BLS: %v", err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := GenerateValidatorsByMnemonic(mnemonicsFile); err != nil {
+			b.Fatalf("failed to generate validators: %v", err)
+		}
+	}
+
+	b.ReportMetric(float64(count*b.N)/b.Elapsed().Seconds(), "validators/s")
+}
+
+func writeBenchmarkMnemonicsFile(path string, count int) error {
+	data := fmt.Sprintf("- mnemonic: \"test test test test test test test test test test test junk\"\n  count: %d\n  wd_type: bls\n", count)
+
+	return os.WriteFile(path, []byte(data), 0o600)
+}
@@ -1,6 +1,9 @@
 package validators
 
 import (
+	"bytes"
+	"errors"
+
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
@@ -8,4 +11,29 @@ type Validator struct {
 	PublicKey             phase0.BLSPubKey
 	WithdrawalCredentials []byte
 	Balance               *uint64
+	Label                 string
+	NonSigning            bool
+	Metadata              map[string]string
+	WithdrawalKeyPath     string
+	Source                string
+	KeyPath               string
+}
+
+// checkWithdrawalCredentials validates length and type prefix of withdrawal credentials.
+func checkWithdrawalCredentials(withdrawalCred []byte) error {
+	if len(withdrawalCred) != 32 {
+		return errors.New("invalid length")
+	}
+
+	switch withdrawalCred[0] {
+	case 0x00:
+	case 0x01, 0x02:
+		if !bytes.Equal(withdrawalCred[1:12], []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}) {
+			return errors.New("invalid 0x01/0x02 cred")
+		}
+	default:
+		return errors.New("invalid type")
+	}
+
+	return nil
 }
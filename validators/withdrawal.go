@@ -0,0 +1,258 @@
+package validators
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// withdrawalAddressIndexPlaceholder is replaced with the zero padded hex account index in withdrawal address templates.
+const withdrawalAddressIndexPlaceholder = "{index}"
+
+// parseWithdrawalType parses a withdrawal credentials type name or prefix.
+func parseWithdrawalType(wdType string) (byte, error) {
+	switch strings.ToLower(wdType) {
+	case "bls", "0x00":
+		return 0x00, nil
+	case "eth1", "execution", "0x01":
+		return 0x01, nil
+	case "compounding", "0x02":
+		return 0x02, nil
+	default:
+		return 0, fmt.Errorf("unknown withdrawal type '%v' (expected bls, eth1 or compounding)", wdType)
+	}
+}
+
+func parseWithdrawalAddress(address string) ([]byte, error) {
+	addressBytes, err := hex.DecodeString(strings.ReplaceAll(address, "0x", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode withdrawal address: %w", err)
+	}
+
+	if len(addressBytes) != 20 {
+		return nil, fmt.Errorf("failed to decode withdrawal address: invalid length (%v bytes)", len(addressBytes))
+	}
+
+	return addressBytes, nil
+}
+
+// withdrawalConfig is the resolved withdrawal credentials configuration of a mnemonic source.
+type withdrawalConfig struct {
+	prefix      byte
+	addresses   [][]byte
+	template    string
+	addressPath *addressPathTemplate
+}
+
+// getWithdrawalConfig validates and resolves the withdrawal settings of a mnemonic source.
+// The seed is used to derive per-validator withdrawal addresses via wd_address_path.
+func (src *MnemonicSrc) getWithdrawalConfig(seed []byte) (*withdrawalConfig, error) {
+	wdConfig := &withdrawalConfig{}
+
+	hasAddress := src.WdAddress != "" || len(src.WdAddresses) > 0 || src.WdAddressTemplate != "" || src.WdAddressPath != ""
+
+	switch {
+	case src.WdType != "":
+		prefix, err := parseWithdrawalType(src.WdType)
+		if err != nil {
+			return nil, err
+		}
+
+		wdConfig.prefix = prefix
+
+		if src.WdPrefix != "" {
+			legacyPrefix, err := parseWithdrawalPrefix(src.WdPrefix)
+			if err != nil {
+				return nil, err
+			}
+
+			if legacyPrefix != prefix {
+				return nil, fmt.Errorf("wd_prefix %v conflicts with wd_type %v", src.WdPrefix, src.WdType)
+			}
+		}
+
+		if prefix == 0x00 && hasAddress {
+			return nil, fmt.Errorf("withdrawal address cannot be used with wd_type %v", src.WdType)
+		}
+	case src.WdPrefix != "":
+		prefix, err := parseWithdrawalPrefix(src.WdPrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		wdConfig.prefix = prefix
+
+		if prefix == 0x00 && hasAddress {
+			// legacy behaviour: 0x00 prefix ignores the withdrawal address
+			return wdConfig, nil
+		}
+	case hasAddress:
+		return nil, errors.New("wd_type is required when setting a withdrawal address")
+	}
+
+	if wdConfig.prefix == 0x00 {
+		return wdConfig, nil
+	}
+
+	if src.WdKeyPath != "" {
+		return nil, errors.New("wd_key_path can only be used with bls withdrawal credentials")
+	}
+
+	addressSettings := 0
+
+	if src.WdAddress != "" {
+		address, err := parseWithdrawalAddress(src.WdAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		wdConfig.addresses = [][]byte{address}
+		addressSettings++
+	}
+
+	if len(src.WdAddresses) > 0 {
+		if uint64(len(src.WdAddresses)) != src.Count {
+			return nil, fmt.Errorf("wd_addresses has %v entries, expected %v (count)", len(src.WdAddresses), src.Count)
+		}
+
+		wdConfig.addresses = make([][]byte, len(src.WdAddresses))
+
+		for i, addressStr := range src.WdAddresses {
+			address, err := parseWithdrawalAddress(addressStr)
+			if err != nil {
+				return nil, fmt.Errorf("wd_addresses[%v]: %w", i, err)
+			}
+
+			wdConfig.addresses[i] = address
+		}
+
+		addressSettings++
+	}
+
+	if src.WdAddressTemplate != "" {
+		if _, err := formatWithdrawalAddressTemplate(src.WdAddressTemplate, src.Start+src.Count-1); err != nil {
+			return nil, err
+		}
+
+		wdConfig.template = src.WdAddressTemplate
+		addressSettings++
+	}
+
+	if src.WdAddressPath != "" {
+		addressPath, err := newAddressPathTemplate(seed, src.WdAddressPath)
+		if err != nil {
+			return nil, err
+		}
+
+		wdConfig.addressPath = addressPath
+		addressSettings++
+	}
+
+	switch addressSettings {
+	case 0:
+		return nil, fmt.Errorf("withdrawal address required for 0x%02x withdrawal credentials", wdConfig.prefix)
+	case 1:
+		return wdConfig, nil
+	default:
+		return nil, errors.New("only one of wd_address, wd_addresses, wd_address_template or wd_address_path can be set")
+	}
+}
+
+func parseWithdrawalPrefix(wdPrefix string) (byte, error) {
+	prefix, err := hex.DecodeString(strings.ReplaceAll(wdPrefix, "0x", ""))
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode withdrawal prefix: %w", err)
+	}
+
+	if len(prefix) != 1 || prefix[0] > 0x02 {
+		return 0, fmt.Errorf("unknown withdrawal prefix %v", wdPrefix)
+	}
+
+	return prefix[0], nil
+}
+
+// getAddress returns the withdrawal address for the i-th validator (account index idx) of the source.
+// For derived addresses, the derivation path is returned as well.
+func (c *withdrawalConfig) getAddress(i, idx uint64) ([]byte, string, error) {
+	switch {
+	case c.addressPath != nil:
+		address, err := c.addressPath.Address(idx)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return address[:], c.addressPath.Path(idx), nil
+	case c.template != "":
+		address, err := formatWithdrawalAddressTemplate(c.template, idx)
+		return address, "", err
+	case len(c.addresses) == 1:
+		return c.addresses[0], "", nil
+	default:
+		return c.addresses[i], "", nil
+	}
+}
+
+// formatWithdrawalAddressTemplate fills the index placeholder of an address template with the zero padded hex index,
+// e.g. "0xdead000000000000000000000000000000{index}" results in 0xdead...0000002a for index 42.
+func formatWithdrawalAddressTemplate(template string, idx uint64) ([]byte, error) {
+	template = strings.TrimPrefix(template, "0x")
+
+	if strings.Count(template, withdrawalAddressIndexPlaceholder) != 1 {
+		return nil, fmt.Errorf("invalid wd_address_template: must contain %v exactly once", withdrawalAddressIndexPlaceholder)
+	}
+
+	width := 40 - (len(template) - len(withdrawalAddressIndexPlaceholder))
+	if width <= 0 {
+		return nil, errors.New("invalid wd_address_template: no space left for index")
+	}
+
+	indexStr := fmt.Sprintf("%0*x", width, idx)
+	if len(indexStr) > width {
+		return nil, fmt.Errorf("invalid wd_address_template: index %v does not fit into %v hex digits", idx, width)
+	}
+
+	address, err := parseWithdrawalAddress(strings.Replace(template, withdrawalAddressIndexPlaceholder, indexStr, 1))
+	if err != nil {
+		return nil, fmt.Errorf("invalid wd_address_template: %w", err)
+	}
+
+	return address, nil
+}
+
+// WithdrawalAddressEntry maps an execution layer withdrawal address to a genesis validator.
+type WithdrawalAddressEntry struct {
+	Index   uint64 `json:"index"`
+	PubKey  string `json:"pubkey"`
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	Path    string `json:"path,omitempty"`
+}
+
+// GetWithdrawalAddresses returns the withdrawal address to validator index mapping for all validators
+// with 0x01 or 0x02 withdrawal credentials. Indexes refer to the position in the given validator list.
+func GetWithdrawalAddresses(validators []*Validator) []*WithdrawalAddressEntry {
+	entries := make([]*WithdrawalAddressEntry, 0)
+
+	for index, validator := range validators {
+		if validator == nil || len(validator.WithdrawalCredentials) != 32 {
+			continue
+		}
+
+		if validator.WithdrawalCredentials[0] != 0x01 && validator.WithdrawalCredentials[0] != 0x02 {
+			continue
+		}
+
+		entries = append(entries, &WithdrawalAddressEntry{
+			Index:   uint64(index),
+			PubKey:  validator.PublicKey.String(),
+			Address: common.BytesToAddress(validator.WithdrawalCredentials[12:]).Hex(),
+			Type:    fmt.Sprintf("0x%02x", validator.WithdrawalCredentials[0]),
+			Path:    validator.WithdrawalKeyPath,
+		})
+	}
+
+	return entries
+}